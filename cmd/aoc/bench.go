@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/bench"
+)
+
+// benchCmd benchmarks every registered solver and variant on the real input,
+// writes the results as JSON and compares them against a stored baseline.
+func benchCmd(args []string) int {
+	arg := "all"
+	if len(args) > 0 && len(args[0]) > 0 && args[0][0] != '-' {
+		arg, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	count := fs.Int("count", 5, "number of samples per benchmark")
+	benchtime := fs.String("benchtime", "1s", "run time per sample, as for go test -benchtime")
+	out := fs.String("o", "bench_output.txt", "write results as JSON to `file`")
+	baseline := fs.String("baseline", "bench_baseline.json", "compare against the results in `file`")
+	save := fs.Bool("save", false, "store the results as the new baseline")
+	threshold := fs.Float64("threshold", 0.05, "relative slowdown that counts as a regression")
+	alpha := fs.Float64("alpha", 0.05, "significance level of the comparison")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *count < 1 {
+		fmt.Fprintln(os.Stderr, "aoc bench: -count must be at least 1")
+		return 2
+	}
+	days, err := selectDays(arg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	// testing.Benchmark reads its run time from the test flags
+	testing.Init()
+	if err := flag.Set("test.benchtime", *benchtime); err != nil {
+		fmt.Fprintln(os.Stderr, "aoc bench: -benchtime:", err)
+		return 2
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	resolve := func(p string) string {
+		if filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(root, p)
+	}
+
+	report := bench.NewReport()
+	status := 0
+	for _, d := range days {
+		lines, err := aoc.ReadInput(aoc.InputPath(root, d.Number))
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("Day%02d: skipped, no input\n", d.Number)
+			continue
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			status = 1
+			continue
+		}
+		for _, b := range d.Benchmarks() {
+			name := fmt.Sprintf("Day%02d/%s", d.Number, b.Name)
+			if _, err := b.Solve(lines); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", name, aoc.Describe(err, lines))
+				status = 1
+				continue
+			}
+			res := bench.Run(name, func(tb *testing.B) {
+				for tb.Loop() {
+					b.Solve(lines)
+				}
+			}, *count)
+			report.Results = append(report.Results, res)
+			fmt.Printf("%-32s %14.0f ns/op %12.0f B/op %10.0f allocs/op\n",
+				name, res.Mean(bench.NsPerOp), res.Mean(bench.BytesPerOp), res.Mean(bench.AllocsPerOp))
+		}
+	}
+
+	if err := report.WriteFile(resolve(*out)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if base, err := bench.ReadFile(resolve(*baseline)); err == nil {
+		deltas := bench.Compare(base, report, *alpha, *threshold)
+		if len(deltas) > 0 {
+			fmt.Printf("\ncompared with %s (%s):\n", *baseline, base.Time.Format("2006-01-02 15:04"))
+		}
+		for _, d := range deltas {
+			verdict := "~"
+			switch {
+			case d.Regression:
+				verdict = "REGRESSION"
+				status = 1
+			case d.Improved:
+				verdict = "improved"
+			}
+			fmt.Printf("%-32s %14.0f -> %14.0f ns/op %+7.1f%%  p=%.3f  %s\n",
+				d.Name, d.Old, d.New, 100*d.Change, d.P, verdict)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *save {
+		if err := report.WriteFile(resolve(*baseline)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Println("saved baseline to", *baseline)
+	}
+	return status
+}
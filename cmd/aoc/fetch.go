@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/site"
+)
+
+// fetchCmd downloads puzzle inputs that are not yet cached under inputs/
+func fetchCmd(args []string) int {
+	arg, _, err := dayArg("fetch", args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	var numbers []int
+	if arg == "all" {
+		for _, d := range aoc.Days() {
+			numbers = append(numbers, d.Number)
+		}
+	} else {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > 25 {
+			fmt.Fprintf(os.Stderr, "aoc fetch: invalid day %q\n", arg)
+			return 2
+		}
+		numbers = []int{n}
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	client := site.New(session())
+	status := 0
+	for _, n := range numbers {
+		path := aoc.InputPath(root, n)
+		fetched, err := client.FetchInput(context.Background(), n, path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "day %d: %v\n", n, err)
+			status = 1
+			continue
+		}
+		rel, _ := filepath.Rel(root, path)
+		if fetched {
+			fmt.Printf("day %d: wrote %s\n", n, rel)
+		} else {
+			fmt.Printf("day %d: %s already cached\n", n, rel)
+		}
+	}
+	return status
+}
+
+// session returns the session cookie from $AOC_SESSION, falling back to
+// the file aoc/session in the user's config directory.
+func session() string {
+	if s := os.Getenv("AOC_SESSION"); s != "" {
+		return strings.TrimSpace(s)
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "aoc", "session"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,93 @@
+// Command aoc runs, tests and manages the Advent of Code solutions.
+//
+// Usage:
+//
+//	aoc run <day|all> [-input file] [-part 1|2|both] [-time]
+//	        [-cpuprofile file] [-memprofile file] [-trace file]
+//	aoc test <day|all> [go test flags]
+//	aoc fetch <day|all>
+//	aoc submit <day> <part> [-input file]
+//	aoc verify [day|all] [-record]
+//	aoc tool <day> [-input file] [name [args]]
+//	aoc new <day> [-template line|grid|graph|sections]
+//	aoc bench [day|all] [-count n] [-benchtime d] [-o file] [-baseline file] [-save] [-threshold f] [-alpha f]
+//
+// Commands that talk to adventofcode.com read the session cookie from
+// $AOC_SESSION or from aoc/session in the user's config directory.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	_ "aoc2025/days"
+	"aoc2025/internal/aoc"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) int
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"run", "run <day|all> [-input file] [-part 1|2|both] [-time] [-cpuprofile file] [-memprofile file] [-trace file]", runCmd},
+		{"test", "test <day|all> [go test flags]", testCmd},
+		{"fetch", "fetch <day|all>", fetchCmd},
+		{"submit", "submit <day> <part> [-input file]", submitCmd},
+		{"verify", "verify [day|all] [-record]", verifyCmd},
+		{"tool", "tool <day> [-input file] [name [args]]", toolCmd},
+		{"new", "new <day> [-template line|grid|graph|sections]", newCmd},
+		{"bench", "bench [day|all] [-count n] [-benchtime d] [-o file] [-baseline file] [-save] [-threshold f] [-alpha f]", benchCmd},
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	for _, c := range commands {
+		fmt.Fprintln(os.Stderr, "  aoc", c.usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			os.Exit(c.run(os.Args[2:]))
+		}
+	}
+	fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+// selectDays resolves a day argument ("all" or a day number) to registered days
+func selectDays(arg string) ([]aoc.Day, error) {
+	if arg == "all" {
+		return aoc.Days(), nil
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day %q", arg)
+	}
+	d, ok := aoc.Lookup(n)
+	if !ok {
+		return nil, fmt.Errorf("day %d is not registered", n)
+	}
+	return []aoc.Day{d}, nil
+}
+
+// dayArg splits the leading day argument off the remaining arguments
+func dayArg(name string, args []string) (string, []string, error) {
+	if len(args) == 0 || len(args[0]) == 0 || args[0][0] == '-' {
+		return "", nil, fmt.Errorf("aoc %s: missing day", name)
+	}
+	return args[0], args[1:], nil
+}
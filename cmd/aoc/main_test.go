@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/site"
+)
+
+func TestSelectDays(t *testing.T) {
+	all, err := selectDays("all")
+	if err != nil || len(all) != 12 {
+		t.Fatalf("selectDays(all) = %d days, %v; want 12", len(all), err)
+	}
+	for i, d := range all {
+		if d.Number != i+1 {
+			t.Errorf("day %d has number %d", i, d.Number)
+		}
+	}
+
+	one, err := selectDays("08")
+	if err != nil || len(one) != 1 || one[0].Number != 8 {
+		t.Errorf("selectDays(08) = %v, %v; want day 8", one, err)
+	}
+
+	for _, arg := range []string{"x", "0", "26"} {
+		if _, err := selectDays(arg); err == nil {
+			t.Errorf("selectDays(%q) succeeded, want error", arg)
+		}
+	}
+}
+
+func TestDayArg(t *testing.T) {
+	day, rest, err := dayArg("run", []string{"3", "-part", "1"})
+	if err != nil || day != "3" || len(rest) != 2 {
+		t.Errorf("dayArg = %q, %v, %v", day, rest, err)
+	}
+	if _, _, err := dayArg("run", []string{"-part", "1"}); err == nil {
+		t.Error("dayArg without a day succeeded, want error")
+	}
+}
+
+func TestRecordAccepted(t *testing.T) {
+	days, err := selectDays("all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	history := &site.Log{Parts: map[string]*site.PartLog{}}
+	history.Record(2, 1, "10", site.Outcome{Verdict: site.TooLow}, now)
+	history.Record(2, 1, "42", site.Outcome{Verdict: site.Correct}, now)
+	history.Record(2, 2, "7", site.Outcome{Verdict: site.Wrong}, now)
+	history.Record(3, 1, "99", site.Outcome{Verdict: site.Correct}, now)
+	answers := aoc.Answers{3: {Part1: "5"}}
+
+	if n := recordAccepted(answers, history, days); n != 1 {
+		t.Errorf("recordAccepted = %d, want 1", n)
+	}
+	if v, ok := answers.Get(2, 1); !ok || v != "42" {
+		t.Errorf("day 2 part 1 = %q, %v; want the confirmed 42", v, ok)
+	}
+	if v, ok := answers.Get(2, 2); ok {
+		t.Errorf("day 2 part 2 = %q, want no answer for a rejected submission", v)
+	}
+	if v, _ := answers.Get(3, 1); v != "5" {
+		t.Errorf("day 3 part 1 = %q, want the stored 5 kept", v)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/scaffold"
+)
+
+// newCmd scaffolds the package, tests and doc stub for a new day
+func newCmd(args []string) int {
+	arg, rest, err := dayArg("new", args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	kind := fs.String("template", "line", "solver template: "+strings.Join(scaffold.Kinds, ", "))
+	if err := fs.Parse(rest); err != nil {
+		return 2
+	}
+	day, err := strconv.Atoi(arg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc new: invalid day %q\n", arg)
+		return 2
+	}
+	root, err := aoc.FindRoot()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	created, err := scaffold.Generate(root, day, *kind)
+	for _, path := range created {
+		fmt.Println("created", path)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aoc new:", err)
+		return 1
+	}
+	fmt.Println("registered in days/all.go")
+	return 0
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"aoc2025/internal/aoc"
+)
+
+func runCmd(args []string) int {
+	arg, rest, err := dayArg("run", args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	var opts aoc.Options
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	opts.SetFlags(fs)
+	if err := fs.Parse(rest); err != nil {
+		return 2
+	}
+	days, err := selectDays(arg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if len(days) > 1 && opts.Input != "" {
+		fmt.Fprintln(os.Stderr, "aoc run: -input only applies to a single day")
+		return 2
+	}
+	if len(days) > 1 && opts.Profiling() {
+		fmt.Fprintln(os.Stderr, "aoc run: profiling only applies to a single day")
+		return 2
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	status := 0
+	for i, d := range days {
+		o := opts
+		if o.Input == "" {
+			o.Input = aoc.InputPath(root, d.Number)
+		}
+		if len(days) > 1 {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("Day %02d\n", d.Number)
+		}
+		status = max(status, d.Run(o, os.Stdin, os.Stdout, os.Stderr))
+	}
+	return status
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/site"
+)
+
+// submitCmd solves one part and submits the answer, consulting the local
+// submission log first so known-wrong answers and lockouts never reach the site.
+func submitCmd(args []string) int {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "aoc submit: want <day> <part>")
+		return 2
+	}
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	input := fs.String("input", "", "read puzzle input from `file` instead of inputs/dayNN.txt")
+	if err := fs.Parse(args[2:]); err != nil {
+		return 2
+	}
+	days, err := selectDays(args[0])
+	if err != nil || len(days) != 1 {
+		fmt.Fprintf(os.Stderr, "aoc submit: invalid day %q\n", args[0])
+		return 2
+	}
+	d := days[0]
+	part, err := strconv.Atoi(args[1])
+	if err != nil || (part != 1 && part != 2) {
+		fmt.Fprintf(os.Stderr, "aoc submit: invalid part %q\n", args[1])
+		return 2
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if *input == "" {
+		*input = aoc.InputPath(root, d.Number)
+	}
+	lines, err := aoc.ReadInput(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	n, err := d.Solve(part, lines)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Part %d: %s\n", part, aoc.Describe(err, lines))
+		return 1
+	}
+	answer := strconv.Itoa(n)
+	fmt.Printf("Day %d part %d answer: %s\n", d.Number, part, answer)
+
+	logPath := site.LogPath(root)
+	history, err := site.LoadLog(logPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := history.Check(d.Number, part, answer, time.Now()); err != nil {
+		fmt.Fprintln(os.Stderr, "not submitting:", err)
+		if errors.Is(err, site.ErrSolved) {
+			return 0
+		}
+		return 1
+	}
+
+	out, err := site.New(session()).Submit(context.Background(), d.Number, part, answer)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	history.Record(d.Number, part, answer, out, time.Now())
+	if err := history.Save(logPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if out.Verdict == site.Correct {
+		answersPath := aoc.AnswersPath(root)
+		answers, err := aoc.LoadAnswers(answersPath)
+		if err == nil {
+			answers.Set(d.Number, part, answer)
+			err = answers.Save(answersPath)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "recording answer:", err)
+		}
+	}
+
+	fmt.Println(out.Verdict)
+	if out.Wait > 0 {
+		fmt.Printf("next attempt allowed in %v\n", out.Wait)
+	}
+	if out.Verdict != site.Correct {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"aoc2025/internal/aoc"
+)
+
+// testCmd runs go test on the selected day packages, passing through extra flags
+func testCmd(args []string) int {
+	arg, rest, err := dayArg("test", args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	days, err := selectDays(arg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	root, err := aoc.FindRoot()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	goArgs := append([]string{"test"}, rest...)
+	for _, d := range days {
+		goArgs = append(goArgs, fmt.Sprintf("./days/day%02d", d.Number))
+	}
+	cmd := exec.Command("go", goArgs...)
+	cmd.Dir = root
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"aoc2025/internal/aoc"
+)
+
+// toolCmd runs one of a day's tools on its input, or lists the tools when
+// no name is given
+func toolCmd(args []string) int {
+	arg, rest, err := dayArg("tool", args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+	input := fs.String("input", "", "read puzzle input from `file` instead of inputs/dayNN.txt")
+	if err := fs.Parse(rest); err != nil {
+		return 2
+	}
+	days, err := selectDays(arg)
+	if err != nil || len(days) != 1 {
+		fmt.Fprintf(os.Stderr, "aoc tool: invalid day %q\n", arg)
+		return 2
+	}
+	d := days[0]
+	if fs.NArg() == 0 {
+		if len(d.Tools) == 0 {
+			fmt.Printf("Day %02d has no tools\n", d.Number)
+		}
+		for _, t := range d.Tools {
+			fmt.Printf("aoc tool %d %s %s\n", d.Number, t.Name, t.Usage)
+		}
+		return 0
+	}
+	t, ok := d.Tool(fs.Arg(0))
+	if !ok {
+		fmt.Fprintf(os.Stderr, "aoc tool: day %d has no tool %q\n", d.Number, fs.Arg(0))
+		return 2
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if *input == "" {
+		*input = aoc.InputPath(root, d.Number)
+	}
+	lines, err := aoc.ReadInput(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := t.Run(lines, fs.Args()[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", t.Name, aoc.Describe(err, lines))
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/site"
+)
+
+// verifyCmd runs every selected day against its input and compares the
+// results with inputs/answers.json.
+func verifyCmd(args []string) int {
+	arg := "all"
+	if len(args) > 0 && len(args[0]) > 0 && args[0][0] != '-' {
+		arg, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	record := fs.Bool("record", false, "first copy answers confirmed by submit into the answer store")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	days, err := selectDays(arg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	root, err := aoc.FindRoot()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	answersPath := aoc.AnswersPath(root)
+	answers, err := aoc.LoadAnswers(answersPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *record {
+		history, err := site.LoadLog(site.LogPath(root))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if n := recordAccepted(answers, history, days); n > 0 {
+			if err := answers.Save(answersPath); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 1
+			}
+		}
+	}
+
+	status := 0
+	for _, d := range days {
+		lines, err := aoc.ReadInput(aoc.InputPath(root, d.Number))
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("Day %02d: skipped, no input\n", d.Number)
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Day %02d: %v\n", d.Number, err)
+			status = 1
+			continue
+		}
+
+		checks := d.Verify(lines, answers)
+		if len(checks) == 0 {
+			fmt.Printf("Day %02d: skipped, no known answers\n", d.Number)
+		}
+		for _, c := range checks {
+			switch {
+			case c.Err != nil:
+				fmt.Printf("Day %02d %s: FAIL %s\n", d.Number, c.Label, aoc.Describe(c.Err, lines))
+				status = 1
+			case !c.OK():
+				fmt.Printf("Day %02d %s: MISMATCH got %d, want %s\n", d.Number, c.Label, c.Got, c.Want)
+				status = 1
+			default:
+				fmt.Printf("Day %02d %s: ok (%v)\n", d.Number, c.Label, c.Elapsed)
+			}
+		}
+	}
+	return status
+}
+
+// recordAccepted copies answers the site has confirmed for days into
+// answers where none is stored yet, and returns how many it added
+func recordAccepted(answers aoc.Answers, history *site.Log, days []aoc.Day) int {
+	recorded := 0
+	for _, d := range days {
+		for p := 1; p <= 2; p++ {
+			if _, ok := answers.Get(d.Number, p); ok {
+				continue
+			}
+			if v, ok := history.Accepted(d.Number, p); ok {
+				answers.Set(d.Number, p, v)
+				recorded++
+				fmt.Printf("Day %02d Part %d: recorded %s\n", d.Number, p, v)
+			}
+		}
+	}
+	return recorded
+}
@@ -1,11 +1,6 @@
-package main
+package day01
 
-import (
-	"bufio"
-	"fmt"
-	"os"
-	"strconv"
-)
+import "aoc2025/internal/aoc"
 
 type Direction int
 
@@ -19,9 +14,9 @@ type Move struct {
 	Amount    int
 }
 
-func parse(lines []string) []Move {
+func parse(lines []string) ([]Move, error) {
 	moves := make([]Move, 0, len(lines))
-	for _, line := range lines {
+	for i, line := range lines {
 		if len(line) == 0 {
 			continue
 		}
@@ -31,11 +26,19 @@ func parse(lines []string) []Move {
 			dir = Left
 		case 'R':
 			dir = Right
+		default:
+			return nil, aoc.ParseErrorf(1, i+1, 1, "direction must be L or R, got %q", line[0])
+		}
+		amount, err := aoc.Atoi(1, i+1, 2, line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if amount < 0 {
+			return nil, aoc.ParseErrorf(1, i+1, 2, "negative amount %d", amount)
 		}
-		amount, _ := strconv.Atoi(line[1:])
 		moves = append(moves, Move{Direction: dir, Amount: amount})
 	}
-	return moves
+	return moves, nil
 }
 
 func part1(moves []Move) int {
@@ -83,14 +86,22 @@ func part2(moves []Move) int {
 	return password
 }
 
-func main() {
-	var lines []string
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	moves := parse(lines)
-	fmt.Println("Part 1:", part1(moves))
-	fmt.Println("Part 2:", part2(moves))
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 1,
+		Part1: func(lines []string) (int, error) {
+			moves, err := parse(lines)
+			if err != nil {
+				return 0, err
+			}
+			return part1(moves), nil
+		},
+		Part2: func(lines []string) (int, error) {
+			moves, err := parse(lines)
+			if err != nil {
+				return 0, err
+			}
+			return part2(moves), nil
+		},
+	})
 }
@@ -0,0 +1,41 @@
+package day01
+
+import (
+	"errors"
+	"testing"
+
+	"aoc2025/internal/aoc"
+)
+
+var example = []string{"L68", "L30", "R48", "L5", "R60", "L55", "L1", "L99", "R14", "L82"}
+
+func TestParts(t *testing.T) {
+	moves, err := parse(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := part1(moves); got != 3 {
+		t.Errorf("part1() = %d, want 3", got)
+	}
+	if got := part2(moves); got != 6 {
+		t.Errorf("part2() = %d, want 6", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		lines     []string
+		line, col int
+	}{
+		{[]string{"L1", "X5"}, 2, 1},
+		{[]string{"R1x"}, 1, 2},
+		{[]string{"", "L"}, 2, 2},
+	}
+	for _, tc := range tests {
+		_, err := parse(tc.lines)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Line != tc.line || pe.Column != tc.col {
+			t.Errorf("parse(%q) error = %v, want ParseError at %d:%d", tc.lines, err, tc.line, tc.col)
+		}
+	}
+}
@@ -1,13 +1,11 @@
-package main
+package day02
 
 import (
-	"bufio"
-	"fmt"
-	"os"
 	"runtime"
-	"strconv"
 	"strings"
 	"sync"
+
+	"aoc2025/internal/aoc"
 )
 
 // isInvalidDouble checks if a number consists of a sequence repeated exactly twice
@@ -63,19 +61,45 @@ func isInvalidRepeated(id int) bool {
 	return false
 }
 
-func part1(lines []string) int {
+type idRange struct{ start, end int }
+
+// parseRanges reads the comma-separated "start-end" list on the first line
+func parseRanges(lines []string) ([]idRange, error) {
 	if len(lines) == 0 {
-		return 0
+		return nil, nil
 	}
-
-	type idRange struct{ start, end int }
 	var ranges []idRange
-
+	col := 1
 	for r := range strings.SplitSeq(lines[0], ",") {
-		startStr, endStr, _ := strings.Cut(r, "-")
-		start, _ := strconv.Atoi(startStr)
-		end, _ := strconv.Atoi(endStr)
+		if r == "" {
+			col++
+			continue
+		}
+		startStr, endStr, ok := strings.Cut(r, "-")
+		if !ok {
+			return nil, aoc.ParseErrorf(2, 1, col, "range %q has no '-'", r)
+		}
+		start, err := aoc.Atoi(2, 1, col, startStr)
+		if err != nil {
+			return nil, err
+		}
+		end, err := aoc.Atoi(2, 1, col+len(startStr)+1, endStr)
+		if err != nil {
+			return nil, err
+		}
+		if end < start {
+			return nil, aoc.ParseErrorf(2, 1, col, "range %q ends before it starts", r)
+		}
 		ranges = append(ranges, idRange{start, end})
+		col += len(r) + 1
+	}
+	return ranges, nil
+}
+
+func part1(lines []string) (int, error) {
+	ranges, err := parseRanges(lines)
+	if err != nil {
+		return 0, err
 	}
 
 	numWorkers := runtime.GOMAXPROCS(0)
@@ -116,22 +140,13 @@ func part1(lines []string) int {
 	for partial := range results {
 		sum += partial
 	}
-	return sum
+	return sum, nil
 }
 
-func part2(lines []string) int {
-	if len(lines) == 0 {
-		return 0
-	}
-
-	type idRange struct{ start, end int }
-	var ranges []idRange
-
-	for r := range strings.SplitSeq(lines[0], ",") {
-		startStr, endStr, _ := strings.Cut(r, "-")
-		start, _ := strconv.Atoi(startStr)
-		end, _ := strconv.Atoi(endStr)
-		ranges = append(ranges, idRange{start, end})
+func part2(lines []string) (int, error) {
+	ranges, err := parseRanges(lines)
+	if err != nil {
+		return 0, err
 	}
 
 	numWorkers := runtime.GOMAXPROCS(0)
@@ -172,16 +187,13 @@ func part2(lines []string) int {
 	for partial := range results {
 		sum += partial
 	}
-	return sum
+	return sum, nil
 }
 
-func main() {
-	var lines []string
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	fmt.Println("Part 1:", part1(lines))
-	fmt.Println("Part 2:", part2(lines))
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 2,
+		Part1:  part1,
+		Part2:  part2,
+	})
 }
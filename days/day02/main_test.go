@@ -1,9 +1,12 @@
-package main
+package day02
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
+
+	"aoc2025/internal/aoc"
 )
 
 func BenchmarkIsInvalidDouble(b *testing.B) {
@@ -64,3 +67,30 @@ func BenchmarkPart2RealInput(b *testing.B) {
 		part2(lines)
 	}
 }
+
+func TestParseRanges(t *testing.T) {
+	ranges, err := parseRanges([]string{"11-22,95-115,"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranges) != 2 || ranges[1] != (idRange{95, 115}) {
+		t.Errorf("parseRanges = %v", ranges)
+	}
+
+	tests := []struct {
+		line string
+		col  int
+	}{
+		{"11-22,95", 7},
+		{"11-22,9x-115", 7},
+		{"11-22,95-1y5", 10},
+		{"22-11", 1},
+	}
+	for _, tc := range tests {
+		_, err := parseRanges([]string{tc.line})
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Line != 1 || pe.Column != tc.col {
+			t.Errorf("parseRanges(%q) error = %v, want column %d", tc.line, err, tc.col)
+		}
+	}
+}
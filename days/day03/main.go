@@ -1,25 +1,39 @@
-package main
+package day03
 
-import (
-	"bufio"
-	"fmt"
-	"os"
-)
+import "aoc2025/internal/aoc"
 
-func part1(lines []string) int {
+// checkDigits rejects lines containing anything other than the digits maxNumber expects
+func checkDigits(lines []string) error {
+	for i, line := range lines {
+		for j := 0; j < len(line); j++ {
+			if line[j] < '0' || line[j] > '9' {
+				return aoc.ParseErrorf(3, i+1, j+1, "expected a digit, got %q", line[j])
+			}
+		}
+	}
+	return nil
+}
+
+func part1(lines []string) (int, error) {
+	if err := checkDigits(lines); err != nil {
+		return 0, err
+	}
 	total := 0
 	for _, line := range lines {
 		total += maxNumber(line, 2)
 	}
-	return total
+	return total, nil
 }
 
-func part2(lines []string) int {
+func part2(lines []string) (int, error) {
+	if err := checkDigits(lines); err != nil {
+		return 0, err
+	}
 	total := 0
 	for _, line := range lines {
 		total += maxNumber(line, 12)
 	}
-	return total
+	return total, nil
 }
 
 // maxNumber finds the maximum number by picking `count` digits from line in order
@@ -51,13 +65,10 @@ func maxNumber(line string, count int) int {
 	return result
 }
 
-func main() {
-	var lines []string
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	fmt.Println("Part 1:", part1(lines))
-	fmt.Println("Part 2:", part2(lines))
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 3,
+		Part1:  part1,
+		Part2:  part2,
+	})
 }
@@ -1,10 +1,13 @@
-package main
+package day03
 
 import (
+	"errors"
 	"math/rand"
 	"strconv"
 	"strings"
 	"testing"
+
+	"aoc2025/internal/aoc"
 )
 
 func generateLines(numLines, lineLength int) []string {
@@ -94,3 +97,14 @@ func BenchmarkPart2Greedy(b *testing.B) {
 		}
 	}
 }
+
+func TestCheckDigits(t *testing.T) {
+	if err := checkDigits([]string{"987654321111111", "811111111111119"}); err != nil {
+		t.Errorf("checkDigits(valid) = %v", err)
+	}
+	err := checkDigits([]string{"1234", "12 4"})
+	var pe *aoc.ParseError
+	if !errors.As(err, &pe) || pe.Line != 2 || pe.Column != 3 {
+		t.Errorf("checkDigits error = %v, want ParseError at 2:3", err)
+	}
+}
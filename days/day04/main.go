@@ -0,0 +1,75 @@
+package day04
+
+import (
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/grid"
+)
+
+// parseGrid reads the map of paper stacks ('@') as a boolean grid
+func parseGrid(lines []string) (*grid.Grid[bool], error) {
+	return grid.Parse(4, lines, func(r rune) bool { return r == '@' })
+}
+
+// accessible reports whether the stack at p has fewer than 4 neighbouring stacks
+func accessible(g *grid.Grid[bool], p grid.Point) bool {
+	adjacent := 0
+	for q := range g.Neighbours8(p) {
+		if g.At(q) {
+			adjacent++
+		}
+	}
+	return adjacent < 4
+}
+
+func part1(lines []string) (int, error) {
+	g, err := parseGrid(lines)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for p, stack := range g.All() {
+		if stack && accessible(g, p) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func part2(lines []string) (int, error) {
+	g, err := parseGrid(lines)
+	if err != nil {
+		return 0, err
+	}
+
+	totalRemoved := 0
+
+	for {
+		// Find all stacks to remove this round
+		var toRemove []grid.Point
+		for p, stack := range g.All() {
+			if stack && accessible(g, p) {
+				toRemove = append(toRemove, p)
+			}
+		}
+
+		if len(toRemove) == 0 {
+			break
+		}
+
+		// Remove all marked stacks
+		for _, p := range toRemove {
+			g.Set(p, false)
+		}
+		totalRemoved += len(toRemove)
+	}
+
+	return totalRemoved, nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 4,
+		Part1:  part1,
+		Part2:  part2,
+	})
+}
@@ -0,0 +1,43 @@
+package day04
+
+import (
+	"errors"
+	"testing"
+
+	"aoc2025/internal/aoc"
+)
+
+var example = []string{
+	"..@@.@@@@.",
+	"@@@.@.@.@@",
+	"@@@@@.@.@@",
+	"@.@@@@..@.",
+	"@@.@@@@.@@",
+	".@@@@@@@.@",
+	".@.@.@.@@@",
+	"@.@@@.@@@@",
+	".@@@@@@@@.",
+	"@.@.@@@.@.",
+}
+
+func TestPart1(t *testing.T) {
+	got, err := part1(example)
+	if err != nil || got != 13 {
+		t.Errorf("part1() = %d, %v; want 13", got, err)
+	}
+}
+
+func TestPart2(t *testing.T) {
+	got, err := part2(example)
+	if err != nil || got != 43 {
+		t.Errorf("part2() = %d, %v; want 43", got, err)
+	}
+}
+
+func TestRaggedGrid(t *testing.T) {
+	_, err := part1([]string{"@@@", "@@", "@@@"})
+	var pe *aoc.ParseError
+	if !errors.As(err, &pe) || pe.Line != 2 {
+		t.Errorf("part1(ragged) error = %v, want ParseError on line 2", err)
+	}
+}
@@ -0,0 +1,78 @@
+package day05
+
+import (
+	"strings"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/interval"
+)
+
+// parseInput reads the fresh ingredient ID ranges, merged into one set,
+// and the available ingredient IDs after the blank line
+func parseInput(lines []string) (interval.Set, []int, error) {
+	var ranges []interval.Interval
+	var numbers []int
+	parsingRanges := true
+
+	for i, line := range lines {
+		if line == "" {
+			parsingRanges = false
+			continue
+		}
+		if parsingRanges {
+			lowStr, highStr, ok := strings.Cut(line, "-")
+			if !ok {
+				return interval.Set{}, nil, aoc.ParseErrorf(5, i+1, 1, "range %q has no '-'", line)
+			}
+			low, err := aoc.Atoi(5, i+1, 1, lowStr)
+			if err != nil {
+				return interval.Set{}, nil, err
+			}
+			high, err := aoc.Atoi(5, i+1, len(lowStr)+2, highStr)
+			if err != nil {
+				return interval.Set{}, nil, err
+			}
+			if high < low {
+				return interval.Set{}, nil, aoc.ParseErrorf(5, i+1, 1, "range %q ends before it starts", line)
+			}
+			ranges = append(ranges, interval.Closed(low, high))
+		} else {
+			n, err := aoc.Atoi(5, i+1, 1, line)
+			if err != nil {
+				return interval.Set{}, nil, err
+			}
+			numbers = append(numbers, n)
+		}
+	}
+	return interval.New(ranges...), numbers, nil
+}
+
+func part1(lines []string) (int, error) {
+	fresh, numbers, err := parseInput(lines)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, n := range numbers {
+		if fresh.Contains(n) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func part2(lines []string) (int, error) {
+	fresh, _, err := parseInput(lines)
+	if err != nil {
+		return 0, err
+	}
+	return fresh.Len(), nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 5,
+		Part1:  part1,
+		Part2:  part2,
+	})
+}
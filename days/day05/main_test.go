@@ -0,0 +1,70 @@
+package day05
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"aoc2025/internal/aoc"
+)
+
+var example = []string{"3-5", "10-14", "16-20", "12-18", "", "1", "5", "8", "11", "17", "32"}
+
+func TestPart1(t *testing.T) {
+	got, err := part1(example)
+	if err != nil || got != 3 {
+		t.Errorf("part1() = %d, %v; want 3", got, err)
+	}
+}
+
+func TestPart2(t *testing.T) {
+	got, err := part2(example)
+	if err != nil || got != 14 {
+		t.Errorf("part2() = %d, %v; want 14", got, err)
+	}
+}
+
+func TestParseInputErrors(t *testing.T) {
+	tests := []struct {
+		lines     []string
+		line, col int
+	}{
+		{[]string{"3-5", "10"}, 2, 1},
+		{[]string{"3-5", "10-1x"}, 2, 4},
+		{[]string{"3-5", "", "7", "x"}, 4, 1},
+		{[]string{"9-5"}, 1, 1},
+	}
+	for _, tc := range tests {
+		_, _, err := parseInput(tc.lines)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Line != tc.line || pe.Column != tc.col {
+			t.Errorf("parseInput(%q) error = %v, want ParseError at %d:%d", tc.lines, err, tc.line, tc.col)
+		}
+	}
+}
+
+func loadInput(b *testing.B) []string {
+	b.Helper()
+	data, err := os.ReadFile("../../inputs/day05.txt")
+	if err != nil {
+		b.Skip("inputs/day05.txt not found")
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func BenchmarkPart1(b *testing.B) {
+	lines := loadInput(b)
+	b.ResetTimer()
+	for b.Loop() {
+		part1(lines)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	lines := loadInput(b)
+	b.ResetTimer()
+	for b.Loop() {
+		part2(lines)
+	}
+}
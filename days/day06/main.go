@@ -1,24 +1,38 @@
-package main
+package day06
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"strconv"
 	"strings"
+
+	"aoc2025/internal/aoc"
 )
 
-func part1(lines []string) int {
+func part1(lines []string) (int, error) {
 	if len(lines) < 2 {
-		return 0
+		return 0, nil
+	}
+
+	// Parse operations (last line)
+	opLine := len(lines)
+	var ops []string
+	for _, f := range aoc.Fields(lines[opLine-1]) {
+		if f.Text != "*" && f.Text != "+" {
+			return 0, aoc.ParseErrorf(6, opLine, f.Column, "operation must be + or *, got %q", f.Text)
+		}
+		ops = append(ops, f.Text)
 	}
 
 	// Parse number rows (all but last line)
 	var columns [][]int
-	for _, line := range lines[:len(lines)-1] {
-		fields := strings.Fields(line)
+	for r, line := range lines[:opLine-1] {
+		fields := aoc.Fields(line)
+		if len(fields) != len(ops) {
+			return 0, aoc.ParseErrorf(6, r+1, 0, "row has %d numbers but there are %d operations", len(fields), len(ops))
+		}
 		for i, f := range fields {
-			num, _ := strconv.Atoi(f)
+			num, err := aoc.Atoi(6, r+1, f.Column, f.Text)
+			if err != nil {
+				return 0, err
+			}
 			if i >= len(columns) {
 				columns = append(columns, []int{})
 			}
@@ -26,9 +40,6 @@ func part1(lines []string) int {
 		}
 	}
 
-	// Parse operations (last line)
-	ops := strings.Fields(lines[len(lines)-1])
-
 	// Calculate each column with its operation
 	total := 0
 	for i, col := range columns {
@@ -44,12 +55,12 @@ func part1(lines []string) int {
 		total += result
 	}
 
-	return total
+	return total, nil
 }
 
-func part2(lines []string) int {
+func part2(lines []string) (int, error) {
 	if len(lines) < 2 {
-		return 0
+		return 0, nil
 	}
 
 	// Find max width
@@ -107,16 +118,13 @@ func part2(lines []string) int {
 		}
 	}
 
-	return total
+	return total, nil
 }
 
-func main() {
-	var lines []string
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	fmt.Println("Part 1:", part1(lines))
-	fmt.Println("Part 2:", part2(lines))
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 6,
+		Part1:  part1,
+		Part2:  part2,
+	})
 }
@@ -0,0 +1,82 @@
+package day06
+
+import (
+	"errors"
+	"testing"
+
+	"aoc2025/internal/aoc"
+)
+
+func TestPart1(t *testing.T) {
+	input := []string{
+		"123 328  51 64 ",
+		" 45 64  387 23 ",
+		"  6 98  215 314",
+		"*   +   *   +  ",
+	}
+	want := 4277556
+	got, err := part1(input)
+	if err != nil || got != want {
+		t.Errorf("part1() = %d, %v; want %d", got, err, want)
+	}
+}
+
+func TestPart1Errors(t *testing.T) {
+	tests := []struct {
+		input     []string
+		line, col int
+	}{
+		{[]string{"1 2", "3 x", "+ *"}, 2, 3},
+		{[]string{"1 2", "3", "+ *"}, 2, 0},
+		{[]string{"1 2", "3 4", "+ -"}, 3, 3},
+	}
+	for _, tc := range tests {
+		_, err := part1(tc.input)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Line != tc.line || pe.Column != tc.col {
+			t.Errorf("part1(%q) error = %v, want ParseError at %d:%d", tc.input, err, tc.line, tc.col)
+		}
+	}
+}
+
+func TestPart2(t *testing.T) {
+	input := []string{
+		"123 328  51 64 ",
+		" 45 64  387 23 ",
+		"  6 98  215 314",
+		"*   +   *   +  ",
+	}
+
+	// Debug: print what we're reading
+	t.Logf("Line lengths: %d, %d, %d, %d", len(input[0]), len(input[1]), len(input[2]), len(input[3]))
+	t.Logf("Line 3: %q", input[3])
+
+	want := 3263827
+	got, err := part2(input)
+
+	if err != nil || got != want {
+		t.Errorf("part2() = %d, %v; want %d", got, err, want)
+	}
+}
+
+func TestPart2Debug(t *testing.T) {
+	input := []string{
+		"123 328  51 64 ",
+		" 45 64  387 23 ",
+		"  6 98  215 314",
+		"*   +   *   +  ",
+	}
+
+	// Trace through manually
+	maxWidth := 15
+	for col := maxWidth - 1; col >= 0; col-- {
+		for row := 0; row < 4; row++ {
+			line := input[row]
+			if col < len(line) {
+				t.Logf("col=%d row=%d char=%q", col, row, line[col])
+			} else {
+				t.Logf("col=%d row=%d OUT OF BOUNDS (len=%d)", col, row, len(line))
+			}
+		}
+	}
+}
@@ -1,35 +1,38 @@
-package main
+package day07
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/grid"
 )
 
-type pos struct {
-	row, col int
+// parseManifold converts the input to a grid and locates the beam start 'S'
+func parseManifold(lines []string) (*grid.Grid[byte], grid.Point, error) {
+	g, err := grid.Parse(7, lines, func(r rune) byte { return byte(r) })
+	if err != nil {
+		return nil, grid.Point{}, err
+	}
+	starts := grid.FindAll(g, 'S')
+	switch {
+	case len(starts) == 0:
+		return nil, grid.Point{}, aoc.ParseErrorf(7, 0, 0, "no start 'S' in input")
+	case len(starts) > 1:
+		return nil, grid.Point{}, aoc.ParseErrorf(7, g.Line(starts[1].Row), starts[1].Col+1, "second start, first was at line %d", g.Line(starts[0].Row))
+	}
+	return g, starts[0], nil
 }
 
-func part1(lines []string) int {
-	grid := make([][]byte, len(lines))
-	var start pos
-	for r, line := range lines {
-		grid[r] = []byte(line)
-		for c, ch := range line {
-			if ch == 'S' {
-				start = pos{r, c}
-			}
-		}
+func part1(lines []string) (int, error) {
+	g, start, err := parseManifold(lines)
+	if err != nil {
+		return 0, err
 	}
 
-	rows, cols := len(grid), len(grid[0])
-
 	// Track which splitters have been hit (by position)
-	splitterHit := make(map[pos]bool)
+	splitterHit := make(map[grid.Point]bool)
 
 	// BFS with beam positions (all beams move down)
-	beams := []pos{start}
-	visited := make(map[pos]bool)
+	beams := []grid.Point{start}
+	visited := make(map[grid.Point]bool)
 	visited[start] = true
 
 	for len(beams) > 0 {
@@ -37,15 +40,14 @@ func part1(lines []string) int {
 		beams = beams[1:]
 
 		// Move down
-		newPos := pos{b.row + 1, b.col}
+		newPos := b.Add(grid.Point{Row: 1})
 
 		// Check bounds
-		if newPos.row >= rows || newPos.col < 0 || newPos.col >= cols {
+		cell, ok := g.Get(newPos)
+		if !ok {
 			continue
 		}
 
-		cell := grid[newPos.row][newPos.col]
-
 		switch cell {
 		case '.', 'S':
 			// Continue down
@@ -58,8 +60,8 @@ func part1(lines []string) int {
 			splitterHit[newPos] = true
 			// Left beam (col-1) and right beam (col+1), both continue down
 			for _, dc := range []int{-1, 1} {
-				splitPos := pos{newPos.row, newPos.col + dc}
-				if splitPos.col >= 0 && splitPos.col < cols && !visited[splitPos] {
+				splitPos := newPos.Add(grid.Point{Col: dc})
+				if g.In(splitPos) && !visited[splitPos] {
 					visited[splitPos] = true
 					beams = append(beams, splitPos)
 				}
@@ -67,23 +69,15 @@ func part1(lines []string) int {
 		}
 	}
 
-	return len(splitterHit)
+	return len(splitterHit), nil
 }
 
-func part2(lines []string) int {
-	grid := make([][]byte, len(lines))
-	var start pos
-	for r, line := range lines {
-		grid[r] = []byte(line)
-		for c, ch := range line {
-			if ch == 'S' {
-				start = pos{r, c}
-			}
-		}
+func part2(lines []string) (int, error) {
+	g, start, err := parseManifold(lines)
+	if err != nil {
+		return 0, err
 	}
 
-	rows, cols := len(grid), len(grid[0])
-
 	// Count paths using dynamic programming
 	// For each position, count how many ways to reach the bottom
 	// We work bottom-up: memo[col] = number of paths from that column at current row
@@ -94,18 +88,13 @@ func part2(lines []string) int {
 	// Actually, let's count paths going down from start
 	// paths[col] = number of distinct paths reaching this column
 	paths := make(map[int]int)
-	paths[start.col] = 1
+	paths[start.Col] = 1
 
-	for row := start.row; row < rows-1; row++ {
+	for row := start.Row; row < g.Height-1; row++ {
 		nextPaths := make(map[int]int)
 
 		for col, count := range paths {
-			nextRow := row + 1
-			if nextRow >= rows {
-				continue
-			}
-
-			cell := grid[nextRow][col]
+			cell := g.At(grid.Point{Row: row + 1, Col: col})
 
 			switch cell {
 			case '.', 'S':
@@ -115,7 +104,7 @@ func part2(lines []string) int {
 				// Splitter: choose left OR right, each choice is a separate path
 				for _, dc := range []int{-1, 1} {
 					newCol := col + dc
-					if newCol >= 0 && newCol < cols {
+					if newCol >= 0 && newCol < g.Width {
 						nextPaths[newCol] += count
 					}
 				}
@@ -130,16 +119,13 @@ func part2(lines []string) int {
 	for _, count := range paths {
 		total += count
 	}
-	return total
+	return total, nil
 }
 
-func main() {
-	var lines []string
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	fmt.Println("Part 1:", part1(lines))
-	fmt.Println("Part 2:", part2(lines))
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 7,
+		Part1:  part1,
+		Part2:  part2,
+	})
 }
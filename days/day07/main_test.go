@@ -0,0 +1,62 @@
+package day07
+
+import (
+	"errors"
+	"testing"
+
+	"aoc2025/internal/aoc"
+)
+
+var testInput = []string{
+	".......S.......",
+	"...............",
+	".......^.......",
+	"...............",
+	"......^.^......",
+	"...............",
+	".....^.^.^.....",
+	"...............",
+	"....^.^...^....",
+	"...............",
+	"...^.^...^.^...",
+	"...............",
+	"..^...^.....^..",
+	"...............",
+	".^.^.^.^.^...^.",
+	"...............",
+}
+
+func TestPart1(t *testing.T) {
+	got, err := part1(testInput)
+	want := 21
+	if err != nil || got != want {
+		t.Errorf("part1() = %d, %v; want %d", got, err, want)
+	}
+}
+
+func TestPart2(t *testing.T) {
+	got, err := part2(testInput)
+	want := 40
+	if err != nil || got != want {
+		t.Errorf("part2() = %d, %v; want %d", got, err, want)
+	}
+}
+
+func TestParseManifoldErrors(t *testing.T) {
+	tests := []struct {
+		input     []string
+		line, col int
+	}{
+		{[]string{"...", "..."}, 0, 0},
+		{[]string{".S.", ".."}, 2, 0},
+		{[]string{".S.", "..S"}, 2, 3},
+		{[]string{"", ".S.", "", "..S"}, 4, 3},
+	}
+	for _, tc := range tests {
+		_, _, err := parseManifold(tc.input)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Line != tc.line || pe.Column != tc.col {
+			t.Errorf("parseManifold(%q) error = %v, want ParseError at %d:%d", tc.input, err, tc.line, tc.col)
+		}
+	}
+}
@@ -1,13 +1,13 @@
-package main
+package day08
 
 import (
-	"bufio"
 	"fmt"
-	"maps"
-	"os"
+	"math"
 	"slices"
-	"strconv"
 	"strings"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/dsu"
 )
 
 type Point struct {
@@ -19,16 +19,33 @@ type Edge struct {
 	i, j   uint16
 }
 
-func parsePoints(lines []string) []Point {
+func parsePoints(lines []string) ([]Point, error) {
 	points := make([]Point, 0, len(lines))
-	for _, line := range lines {
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
 		parts := strings.Split(line, ",")
-		x, _ := strconv.Atoi(parts[0])
-		y, _ := strconv.Atoi(parts[1])
-		z, _ := strconv.Atoi(parts[2])
-		points = append(points, Point{x, y, z})
+		if len(parts) != 3 {
+			return nil, aoc.ParseErrorf(8, i+1, 0, "want 3 comma-separated coordinates, got %d", len(parts))
+		}
+		var coords [3]int
+		col := 1
+		for j, p := range parts {
+			n, err := aoc.Atoi(8, i+1, col, p)
+			if err != nil {
+				return nil, err
+			}
+			coords[j] = n
+			col += len(p) + 1
+		}
+		points = append(points, Point{coords[0], coords[1], coords[2]})
+	}
+	// Edges store point indices as uint16
+	if len(points) > math.MaxUint16+1 {
+		return nil, aoc.ParseErrorf(8, 0, 0, "%d points, at most %d supported", len(points), math.MaxUint16+1)
 	}
-	return points
+	return points, nil
 }
 
 func distanceSquared(a, b Point) int {
@@ -89,8 +106,11 @@ func (h EdgeHeap) init() {
 }
 
 // buildEdgeHeap parses points and returns them with all edges in a min-heap
-func buildEdgeHeap(lines []string) ([]Point, *EdgeHeap) {
-	points := parsePoints(lines)
+func buildEdgeHeap(lines []string) ([]Point, *EdgeHeap, error) {
+	points, err := parsePoints(lines)
+	if err != nil {
+		return nil, nil, err
+	}
 	n := len(points)
 
 	edges := make(EdgeHeap, 0, n*(n-1)/2)
@@ -101,103 +121,72 @@ func buildEdgeHeap(lines []string) ([]Point, *EdgeHeap) {
 	}
 	edges.init()
 
-	return points, &edges
-}
-
-// Union-Find with path compression and union by size
-type UnionFind struct {
-	parent []int
-	size   []int
-}
-
-func newUnionFind(n int) *UnionFind {
-	parent := make([]int, n)
-	size := make([]int, n)
-	for i := range parent {
-		parent[i] = i
-		size[i] = 1
-	}
-	return &UnionFind{parent, size}
-}
-
-func (uf *UnionFind) find(x int) int {
-	if uf.parent[x] != x {
-		uf.parent[x] = uf.find(uf.parent[x])
-	}
-	return uf.parent[x]
+	return points, &edges, nil
 }
 
-func (uf *UnionFind) union(x, y int) bool {
-	px, py := uf.find(x), uf.find(y)
-	if px == py {
-		return false // already in same circuit
+func part1(lines []string) (int, error) {
+	points, edges, err := buildEdgeHeap(lines)
+	if err != nil {
+		return 0, err
 	}
-	// Union by size: attach smaller tree under larger
-	if uf.size[px] < uf.size[py] {
-		px, py = py, px
-	}
-	uf.parent[py] = px
-	uf.size[px] += uf.size[py]
-	return true
-}
-
-func part1(lines []string) int {
-	points, edges := buildEdgeHeap(lines)
 	n := len(points)
 
 	// Connect 1000 closest pairs
-	uf := newUnionFind(n)
+	uf := dsu.New(n)
 	connections := 0
 	for edges.Len() > 0 && connections < 1000 {
 		e := edges.pop()
-		uf.union(int(e.i), int(e.j))
+		uf.Union(int(e.i), int(e.j))
 		connections++
 	}
 
 	// Collect circuit sizes
-	sizes := make(map[int]int)
-	for i := range n {
-		root := uf.find(i)
-		sizes[root] = uf.size[root]
+	sizeList := make([]int, 0, uf.Count())
+	for _, c := range uf.Components() {
+		sizeList = append(sizeList, len(c))
 	}
 
 	// Sort descending and multiply top 3
-	sizeList := slices.Collect(maps.Values(sizes))
 	slices.Sort(sizeList)
 	slices.Reverse(sizeList)
+	if len(sizeList) < 3 {
+		return 0, fmt.Errorf("only %d circuits, need at least 3", len(sizeList))
+	}
 
-	return sizeList[0] * sizeList[1] * sizeList[2]
+	return sizeList[0] * sizeList[1] * sizeList[2], nil
 }
 
-func part2(lines []string) int {
-	points, edges := buildEdgeHeap(lines)
+func part2(lines []string) (int, error) {
+	points, edges, err := buildEdgeHeap(lines)
+	if err != nil {
+		return 0, err
+	}
+	if len(points) < 2 {
+		return 0, fmt.Errorf("need at least 2 junction boxes, got %d", len(points))
+	}
 	n := len(points)
 
 	// Connect until all in one circuit
-	uf := newUnionFind(n)
+	uf := dsu.New(n)
 	var lastEdge Edge
 	for edges.Len() > 0 {
 		e := edges.pop()
-		if uf.union(int(e.i), int(e.j)) {
+		if uf.Union(int(e.i), int(e.j)) {
 			lastEdge = e
-			// Check if all connected (root's size equals n)
-			if uf.size[uf.find(int(e.i))] == n {
+			if uf.Count() == 1 {
 				break
 			}
 		}
 	}
 
 	// Multiply X coordinates of last connected pair
-	return points[lastEdge.i].x * points[lastEdge.j].x
+	return points[lastEdge.i].x * points[lastEdge.j].x, nil
 }
 
-func main() {
-	var lines []string
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	fmt.Println("Part 1:", part1(lines))
-	fmt.Println("Part 2:", part2(lines))
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 8,
+		Part1:  part1,
+		Part2:  part2,
+	})
 }
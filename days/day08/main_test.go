@@ -0,0 +1,67 @@
+package day08
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"aoc2025/internal/aoc"
+)
+
+var example = []string{
+	"162,817,812", "57,618,57", "906,360,560", "592,479,940", "352,342,300",
+	"466,668,158", "542,29,236", "431,825,988", "739,650,466", "52,470,668",
+	"216,146,977", "819,987,18", "117,168,530", "805,96,715", "346,949,466",
+	"970,615,88", "941,993,340", "862,61,35", "984,92,344", "425,690,689",
+}
+
+func TestPart2(t *testing.T) {
+	got, err := part2(example)
+	if err != nil || got != 25272 {
+		t.Errorf("part2() = %d, %v; want 25272", got, err)
+	}
+}
+
+func TestParsePointsErrors(t *testing.T) {
+	tests := []struct {
+		lines     []string
+		line, col int
+	}{
+		{[]string{"1,2,3", "4,5"}, 2, 0},
+		{[]string{"1,2,3", "4,x,6"}, 2, 3},
+		{[]string{"10,20,3z"}, 1, 7},
+	}
+	for _, tc := range tests {
+		_, err := parsePoints(tc.lines)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Line != tc.line || pe.Column != tc.col {
+			t.Errorf("parsePoints(%q) error = %v, want ParseError at %d:%d", tc.lines, err, tc.line, tc.col)
+		}
+	}
+}
+
+func loadInput(b *testing.B) []string {
+	b.Helper()
+	data, err := os.ReadFile("../../inputs/day08.txt")
+	if err != nil {
+		b.Skip("inputs/day08.txt not found")
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func BenchmarkPart1(b *testing.B) {
+	lines := loadInput(b)
+	b.ResetTimer()
+	for b.Loop() {
+		part1(lines)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	lines := loadInput(b)
+	b.ResetTimer()
+	for b.Loop() {
+		part2(lines)
+	}
+}
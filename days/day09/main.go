@@ -0,0 +1,99 @@
+package day09
+
+import (
+	"strings"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/geom"
+)
+
+func parseInput(lines []string) ([]geom.Point, error) {
+	points := make([]geom.Point, 0, len(lines))
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		xStr, yStr, ok := strings.Cut(line, ",")
+		if !ok {
+			return nil, aoc.ParseErrorf(9, i+1, 0, "want x,y")
+		}
+		x, err := aoc.Atoi(9, i+1, 1, xStr)
+		if err != nil {
+			return nil, err
+		}
+		y, err := aoc.Atoi(9, i+1, len(xStr)+2, yStr)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, geom.Point{X: x, Y: y})
+	}
+	return points, nil
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func part1(lines []string) (int, error) {
+	points, err := parseInput(lines)
+	if err != nil {
+		return 0, err
+	}
+	maxArea := 0
+
+	// Check all pairs of points as opposite corners
+	for i := range len(points) {
+		for j := i + 1; j < len(points); j++ {
+			p1, p2 := points[i], points[j]
+			// For opposite corners, x and y must both differ
+			if p1.X != p2.X && p1.Y != p2.Y {
+				area := (abs(p2.X-p1.X) + 1) * (abs(p2.Y-p1.Y) + 1)
+				if area > maxArea {
+					maxArea = area
+				}
+			}
+		}
+	}
+
+	return maxArea, nil
+}
+
+func part2(lines []string) (int, error) {
+	points, err := parseInput(lines)
+	if err != nil {
+		return 0, err
+	}
+	poly, err := geom.NewPolygon(points)
+	if err != nil {
+		return 0, err
+	}
+
+	// For each pair of red points, check if the rectangle between them is
+	// made only of red and green tiles
+	maxArea := 0
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			p1, p2 := points[i], points[j]
+			if p1.X == p2.X || p1.Y == p2.Y {
+				continue
+			}
+			area := (abs(p2.X-p1.X) + 1) * (abs(p2.Y-p1.Y) + 1)
+			if area > maxArea && poly.ContainsRect(p1, p2) {
+				maxArea = area
+			}
+		}
+	}
+
+	return maxArea, nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 9,
+		Part1:  part1,
+		Part2:  part2,
+	})
+}
@@ -1,8 +1,11 @@
-package main
+package day09
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+
+	"aoc2025/internal/aoc"
 )
 
 // generateLargePolygon creates a rectangular polygon with approximately n red tiles
@@ -72,10 +75,10 @@ func TestPart1Example(t *testing.T) {
 		"2,3",
 		"7,3",
 	}
-	got := part1(input)
+	got, err := part1(input)
 	want := 50
-	if got != want {
-		t.Errorf("part1() = %d, want %d", got, want)
+	if err != nil || got != want {
+		t.Errorf("part1() = %d, %v; want %d", got, err, want)
 	}
 }
 
@@ -90,10 +93,35 @@ func TestPart2Example(t *testing.T) {
 		"2,3",
 		"7,3",
 	}
-	got := part2(input)
+	got, err := part2(input)
 	want := 24
-	if got != want {
-		t.Errorf("part2() = %d, want %d", got, want)
+	if err != nil || got != want {
+		t.Errorf("part2() = %d, %v; want %d", got, err, want)
+	}
+
+	// Too few red tiles to enclose anything is an error however few there are
+	for _, input := range [][]string{nil, {"1,1", "1,5"}, {"1,1", "1,5", "4,5"}} {
+		if _, err := part2(input); err == nil {
+			t.Errorf("part2(%q) succeeded, want error", input)
+		}
+	}
+}
+
+func TestParseInputErrors(t *testing.T) {
+	tests := []struct {
+		lines     []string
+		line, col int
+	}{
+		{[]string{"7,1", "11"}, 2, 0},
+		{[]string{"7,1", "x,1"}, 2, 1},
+		{[]string{"7,1", "11,-"}, 2, 4},
+	}
+	for _, tc := range tests {
+		_, err := parseInput(tc.lines)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Line != tc.line || pe.Column != tc.col {
+			t.Errorf("parseInput(%q) error = %v, want ParseError at %d:%d", tc.lines, err, tc.line, tc.col)
+		}
 	}
 }
 
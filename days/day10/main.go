@@ -0,0 +1,234 @@
+package day10
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/ilp"
+	"aoc2025/internal/linalg"
+)
+
+type Machine struct {
+	numLights int
+	target    []bool  // target light pattern
+	buttons   [][]int // each button is a list of light indices it toggles
+	joltages  []int   // target joltage levels for part2
+}
+
+// parseMachine parses one machine line. Errors carry a column but no line;
+// callers add it with aoc.WithLine.
+func parseMachine(line string) (Machine, error) {
+	// Format: [lights] (button1) (button2) ... {joltages}
+	m := Machine{}
+
+	// Extract [lights]
+	start := strings.IndexByte(line, '[')
+	if start == -1 {
+		return m, aoc.ParseErrorf(10, 0, 1, "missing [lights]")
+	}
+	end := strings.IndexByte(line, ']')
+	if end < start {
+		return m, aoc.ParseErrorf(10, 0, start+1, "unterminated [lights]")
+	}
+	lights := line[start+1 : end]
+	m.numLights = len(lights)
+	m.target = make([]bool, m.numLights)
+	for i, ch := range lights {
+		if ch != '#' && ch != '.' {
+			return m, aoc.ParseErrorf(10, 0, start+2+i, "light must be '#' or '.', got %q", ch)
+		}
+		m.target[i] = (ch == '#')
+	}
+
+	// Extract buttons (...) up to the joltages
+	var buttonCols [][]int
+	restStart := end + 1
+	restEnd := len(line)
+	if j := strings.IndexByte(line, '{'); j != -1 {
+		restEnd = j
+	}
+	for pos := restStart; pos < restEnd; {
+		start := strings.IndexByte(line[pos:restEnd], '(')
+		if start == -1 {
+			break
+		}
+		start += pos
+		end := strings.IndexByte(line[start:restEnd], ')')
+		if end == -1 {
+			return m, aoc.ParseErrorf(10, 0, start+1, "unterminated button")
+		}
+		end += start
+
+		button, cols, err := parseList(line, start+1, end)
+		if err != nil {
+			return m, err
+		}
+		for k, idx := range button {
+			if idx < 0 || idx >= m.numLights {
+				return m, aoc.ParseErrorf(10, 0, cols[k], "button toggles light %d, machine has %d", idx, m.numLights)
+			}
+		}
+		m.buttons = append(m.buttons, button)
+		buttonCols = append(buttonCols, cols)
+		pos = end + 1
+	}
+
+	// Extract joltages {...}
+	jStart := strings.IndexByte(line, '{')
+	if jStart != -1 {
+		jEnd := strings.IndexByte(line[jStart:], '}')
+		if jEnd == -1 {
+			return m, aoc.ParseErrorf(10, 0, jStart+1, "unterminated joltages")
+		}
+		joltages, cols, err := parseList(line, jStart+1, jStart+jEnd)
+		if err != nil {
+			return m, err
+		}
+		for k, j := range joltages {
+			if j < 0 {
+				return m, aoc.ParseErrorf(10, 0, cols[k], "negative joltage %d", j)
+			}
+		}
+		for btn, button := range m.buttons {
+			for k, idx := range button {
+				if idx >= len(joltages) {
+					return m, aoc.ParseErrorf(10, 0, buttonCols[btn][k], "button increments counter %d, machine has %d", idx, len(joltages))
+				}
+			}
+		}
+		m.joltages = joltages
+	}
+
+	return m, nil
+}
+
+// parseList parses the comma-separated integers in line[from:to],
+// returning each value with its 1-based column
+func parseList(line string, from, to int) ([]int, []int, error) {
+	list := []int{}
+	var cols []int
+	offset := from
+	for _, s := range strings.Split(line[from:to], ",") {
+		trimmed := strings.TrimSpace(s)
+		if trimmed != "" {
+			col := offset + strings.Index(s, trimmed) + 1
+			n, err := aoc.Atoi(10, 0, col, trimmed)
+			if err != nil {
+				return nil, nil, err
+			}
+			list = append(list, n)
+			cols = append(cols, col)
+		}
+		offset += len(s) + 1
+	}
+	return list, cols, nil
+}
+
+// solveGF2 finds the fewest button presses that set the lights to the
+// target, or -1 if no combination does. Pressing a button twice undoes it,
+// so each button is pressed at most once: solve A x = target over GF(2)
+// and minimise the weight of x over the particular solution plus every
+// combination of the null-space basis.
+func solveGF2(m Machine) int {
+	a := linalg.NewGF2(m.numLights, len(m.buttons))
+	for btn, button := range m.buttons {
+		for _, light := range button {
+			a.Set(light, btn, true)
+		}
+	}
+	target := linalg.NewBits(m.numLights)
+	for i, on := range m.target {
+		target.Set(i, on)
+	}
+
+	x, ok := a.Solve(target)
+	if !ok {
+		return -1
+	}
+	null := a.NullSpace()
+
+	// Walk all 2^k combinations in Gray-code order, flipping one basis
+	// vector per step
+	best := x.OnesCount()
+	for step := 1; step < 1<<len(null); step++ {
+		x.Xor(null[bits.TrailingZeros(uint(step))])
+		best = min(best, x.OnesCount())
+	}
+	return best
+}
+
+func part1(lines []string) (int, error) {
+	total := 0
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		machine, err := parseMachine(line)
+		if err != nil {
+			return 0, aoc.WithLine(err, i+1)
+		}
+		presses := solveGF2(machine)
+		if presses >= 0 {
+			total += presses
+		}
+	}
+	return total, nil
+}
+
+func part2(lines []string) (int, error) {
+	total := 0
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		machine, err := parseMachine(line)
+		if err != nil {
+			return 0, aoc.WithLine(err, i+1)
+		}
+		if len(machine.joltages) == 0 {
+			return 0, aoc.ParseErrorf(10, i+1, 0, "machine has no {joltages}")
+		}
+		presses, err := solveJoltage(machine)
+		if err != nil {
+			return 0, fmt.Errorf("machine on line %d: %w", i+1, err)
+		}
+		total += presses
+	}
+	return total, nil
+}
+
+// solveJoltage finds the fewest button presses that raise every counter
+// to its target joltage, failing with ilp.ErrInfeasible if none do. This is
+// the integer program minimise sum(x) subject to A x = joltages, x >= 0,
+// where A[counter][button] is 1 when the button increments the counter.
+func solveJoltage(m Machine) (int, error) {
+	p := ilp.Problem{
+		A: make([][]int, len(m.joltages)),
+		B: m.joltages,
+		C: make([]int, len(m.buttons)),
+	}
+	for i := range p.A {
+		p.A[i] = make([]int, len(m.buttons))
+	}
+	for btn, button := range m.buttons {
+		p.C[btn] = 1
+		for _, counter := range button {
+			p.A[counter][btn] = 1
+		}
+	}
+	sol, err := ilp.Minimize(p)
+	if err != nil {
+		return 0, err
+	}
+	return sol.Value, nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 10,
+		Part1:  part1,
+		Part2:  part2,
+	})
+}
@@ -0,0 +1,153 @@
+package day10
+
+import (
+	"errors"
+	"testing"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/ilp"
+)
+
+func TestParseMachine(t *testing.T) {
+	line := "[.##.] (3) (1,3) (2) (2,3) (0,2) (0,1) {3,5,4,7}"
+	m, err := parseMachine(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("Machine: numLights=%d, target=%v, buttons=%v", m.numLights, m.target, m.buttons)
+
+	if m.numLights != 4 {
+		t.Errorf("Expected 4 lights, got %d", m.numLights)
+	}
+
+	// [.##.] means lights 1 and 2 should be on (true), 0 and 3 off (false)
+	expectedTarget := []bool{false, true, true, false}
+	for i, v := range expectedTarget {
+		if m.target[i] != v {
+			t.Errorf("target[%d]: expected %v, got %v", i, v, m.target[i])
+		}
+	}
+}
+
+func TestSolveGF2(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected int
+	}{
+		{"[.##.] (3) (1,3) (2) (2,3) (0,2) (0,1) {3,5,4,7}", 2},
+		{"[...#.] (0,2,3,4) (2,3) (0,4) (0,1,2) (1,2,3,4) {7,5,12,7,2}", 3},
+		{"[.###.#] (0,1,2,3,4) (0,3,4) (0,1,2,4,5) (1,2) {10,11,11,5,10,5}", 2},
+		{"[#.] (1) {1,1}", -1},
+	}
+
+	for _, tt := range tests {
+		m, err := parseMachine(tt.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("Machine: numLights=%d, target=%v, buttons=%v", m.numLights, m.target, m.buttons)
+		if result := solveGF2(m); result != tt.expected {
+			t.Errorf("solveGF2(%s) = %d, want %d", tt.line, result, tt.expected)
+		}
+	}
+}
+
+func TestPart1(t *testing.T) {
+	input := []string{
+		"[.##.] (3) (1,3) (2) (2,3) (0,2) (0,1) {3,5,4,7}",
+		"[...#.] (0,2,3,4) (2,3) (0,4) (0,1,2) (1,2,3,4) {7,5,12,7,2}",
+		"[.###.#] (0,1,2,3,4) (0,3,4) (0,1,2,4,5) (1,2) {10,11,11,5,10,5}",
+	}
+
+	result, err := part1(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Part 1 result: %d (expected 7)", result)
+
+	if result != 7 {
+		t.Errorf("Expected 7, got %d", result)
+	}
+}
+
+func TestPart2(t *testing.T) {
+	input := []string{
+		"[.##.] (3) (1,3) (2) (2,3) (0,2) (0,1) {3,5,4,7}",
+		"[...#.] (0,2,3,4) (2,3) (0,4) (0,1,2) (1,2,3,4) {7,5,12,7,2}",
+		"[.###.#] (0,1,2,3,4) (0,3,4) (0,1,2,4,5) (1,2) {10,11,11,5,10,5}",
+	}
+
+	result, err := part2(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Part 2 result: %d (expected 33)", result)
+
+	if result != 33 {
+		t.Errorf("Expected 33, got %d", result)
+	}
+}
+
+func TestSolveJoltage(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected int
+	}{
+		{"[.##.] (3) (1,3) (2) (2,3) (0,2) (0,1) {3,5,4,7}", 10},
+		{"[...#.] (0,2,3,4) (2,3) (0,4) (0,1,2) (1,2,3,4) {7,5,12,7,2}", 12},
+		{"[.###.#] (0,1,2,3,4) (0,3,4) (0,1,2,4,5) (1,2) {10,11,11,5,10,5}", 11},
+		// Nine free buttons with values up to 40 each
+		{"[....] (0) (1) (2) (3) (0,1) (1,2) (2,3) (0,3) (0,2) (1,3) (0,1,2) (1,2,3) (0,1,2,3) {40,40,40,40}", 40},
+	}
+	for _, tt := range tests {
+		m, err := parseMachine(tt.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result, err := solveJoltage(m); err != nil || result != tt.expected {
+			t.Errorf("solveJoltage(%s) = %d, %v; want %d", tt.line, result, err, tt.expected)
+		}
+	}
+
+	m, err := parseMachine("[..] (0,1) {1,2}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := solveJoltage(m); !errors.Is(err, ilp.ErrInfeasible) {
+		t.Errorf("solveJoltage on unreachable joltages: error = %v, want ilp.ErrInfeasible", err)
+	}
+	if _, err := part2([]string{"[..] (0,1) {1,2}"}); !errors.Is(err, ilp.ErrInfeasible) {
+		t.Errorf("part2 on unreachable joltages: error = %v, want ilp.ErrInfeasible", err)
+	}
+}
+
+func TestParseMachineErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		col  int
+	}{
+		{"(0) {1}", 1},
+		{"[.## (0) {1}", 1},
+		{"[.x] (0) {1}", 3},
+		{"[..] (0) (1,x) {1,2}", 13},
+		{"[..] (0) (1,5) {1,2}", 13},
+		{"[...] (0) (1,2) {1,2}", 14},
+		{"[..] (0 {1,2}", 6},
+		{"[..] (0) {1,-2}", 13},
+		{"[..] (0) {1,2", 10},
+	}
+	for _, tc := range tests {
+		_, err := parseMachine(tc.line)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Column != tc.col {
+			t.Errorf("parseMachine(%q) error = %v, want ParseError at column %d", tc.line, err, tc.col)
+		}
+	}
+
+	_, err := part1([]string{"[.#] (0) (1) {1,1}", "", "[..] (7) {1,1}"})
+	var pe *aoc.ParseError
+	if !errors.As(err, &pe) || pe.Line != 3 || pe.Column != 7 {
+		t.Errorf("part1 error = %v, want ParseError at 3:7", err)
+	}
+}
@@ -0,0 +1,83 @@
+package day11
+
+import (
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/graph"
+)
+
+func parseGraph(lines []string) (*graph.Graph, error) {
+	return graph.Parse(11, lines)
+}
+
+// countPaths counts the distinct paths from one named node to another by
+// summing path counts in topological order over the nodes reachable from
+// the start. Missing nodes have no paths.
+func countPaths(g *graph.Graph, from, to string) (int, error) {
+	src, ok := g.ID(from)
+	if !ok {
+		return 0, nil
+	}
+	dst, ok := g.ID(to)
+	if !ok {
+		return 0, nil
+	}
+	order, err := g.TopoSort(src)
+	if err != nil {
+		return 0, err
+	}
+	ways := make([]int, g.Len())
+	ways[src] = 1
+	for _, v := range order {
+		for _, w := range g.Out(v) {
+			ways[w] += ways[v]
+		}
+	}
+	return ways[dst], nil
+}
+
+// countRoute multiplies the path counts between consecutive stops
+func countRoute(g *graph.Graph, stops ...string) (int, error) {
+	total := 1
+	for i := 1; i < len(stops) && total > 0; i++ {
+		n, err := countPaths(g, stops[i-1], stops[i])
+		if err != nil {
+			return 0, err
+		}
+		total *= n
+	}
+	return total, nil
+}
+
+func part1(lines []string) (int, error) {
+	g, err := parseGraph(lines)
+	if err != nil {
+		return 0, err
+	}
+	return countPaths(g, "you", "out")
+}
+
+func part2(lines []string) (int, error) {
+	g, err := parseGraph(lines)
+	if err != nil {
+		return 0, err
+	}
+	// In a DAG at most one of the two orders can have paths, since paths
+	// dac->fft and fft->dac together would form a cycle
+	viaDac, err := countRoute(g, "svr", "dac", "fft", "out")
+	if err != nil {
+		return 0, err
+	}
+	viaFft, err := countRoute(g, "svr", "fft", "dac", "out")
+	if err != nil {
+		return 0, err
+	}
+	return viaDac + viaFft, nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 11,
+		Part1:  part1,
+		Part2:  part2,
+	})
+}
@@ -0,0 +1,128 @@
+package day11
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"testing"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/graph"
+)
+
+var exampleInput = []string{
+	"aaa: you hhh",
+	"you: bbb ccc",
+	"bbb: ddd eee",
+	"ccc: ddd eee fff",
+	"ddd: ggg",
+	"eee: out",
+	"fff: out",
+	"ggg: out",
+	"hhh: ccc fff iii",
+	"iii: out",
+}
+
+func TestPart1(t *testing.T) {
+	got, err := part1(exampleInput)
+	want := 5
+	if err != nil || got != want {
+		t.Errorf("part1() = %d, %v; want %d", got, err, want)
+	}
+}
+
+var exampleInput2 = []string{
+	"svr: aaa bbb",
+	"aaa: fft",
+	"fft: ccc",
+	"bbb: tty",
+	"tty: ccc",
+	"ccc: ddd eee",
+	"ddd: hub",
+	"hub: fff",
+	"eee: dac",
+	"dac: fff",
+	"fff: ggg hhh",
+	"ggg: out",
+	"hhh: out",
+}
+
+func TestPart2(t *testing.T) {
+	got, err := part2(exampleInput2)
+	want := 2
+	if err != nil || got != want {
+		t.Errorf("part2() = %d, %v; want %d", got, err, want)
+	}
+}
+
+func TestParseGraphErrors(t *testing.T) {
+	tests := []struct {
+		lines     []string
+		line, col int
+	}{
+		{[]string{"aaa: bbb", "bbb ccc"}, 2, 0},
+		{[]string{": bbb"}, 1, 1},
+		{[]string{"aaa: bbb", "aaa: ccc"}, 2, 1},
+	}
+	for _, tc := range tests {
+		_, err := parseGraph(tc.lines)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Line != tc.line || pe.Column != tc.col {
+			t.Errorf("parseGraph(%q) error = %v, want ParseError at %d:%d", tc.lines, err, tc.line, tc.col)
+		}
+	}
+}
+
+func TestCycle(t *testing.T) {
+	lines := []string{"you: out aaa", "aaa: bbb", "bbb: aaa"}
+	if _, err := part1(lines); !errors.Is(err, graph.ErrCycle) {
+		t.Errorf("part1(cycle) error = %v, want ErrCycle", err)
+	}
+	// A cycle that cannot be reached from you does not matter
+	got, err := part1([]string{"you: out", "aaa: bbb", "bbb: aaa"})
+	if err != nil || got != 1 {
+		t.Errorf("part1(unreachable cycle) = %d, %v; want 1", got, err)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	for b.Loop() {
+		part1(exampleInput)
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	for b.Loop() {
+		part2(exampleInput2)
+	}
+}
+
+func loadInput(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+func BenchmarkPart1Real(b *testing.B) {
+	lines := loadInput("../../inputs/day11.txt")
+	b.ResetTimer()
+	for b.Loop() {
+		part1(lines)
+	}
+}
+
+func BenchmarkPart2Real(b *testing.B) {
+	lines := loadInput("../../inputs/day11.txt")
+	b.ResetTimer()
+	for b.Loop() {
+		part2(lines)
+	}
+}
@@ -0,0 +1,94 @@
+package day12
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+
+	"aoc2025/internal/dlx"
+)
+
+// placement puts one orientation of a shape with its top-left corner at (row, col)
+type placement struct {
+	shape    int
+	mask     *ShapeMask
+	row, col int
+}
+
+// cells calls f with every cell the placement covers
+func (p placement) cells(f func(r, c int)) {
+	for i, mask := range p.mask.rowMasks {
+		for m := mask; m != 0; m &= m - 1 {
+			f(p.row+i, p.col+bits.TrailingZeros64(m))
+		}
+	}
+}
+
+// exactCover encodes a region as an exact-cover problem. Every shape is a
+// primary column that must be covered once per copy, so copies are placed
+// as a set rather than in every order, and every cell is a secondary
+// column, so presents never overlap but cells may be left empty.
+type exactCover struct {
+	matrix     *dlx.Matrix
+	placements []placement // distinct placements by shape then orientation, one per matrix row
+}
+
+func newExactCover(allMasks [][]ShapeMask, region Region) *exactCover {
+	shapes := 0
+	for shapeIdx, n := range region.counts {
+		if n > 0 && len(allMasks[shapeIdx]) > 0 {
+			shapes++
+		}
+	}
+	ec := &exactCover{matrix: dlx.New(shapes, region.width*region.height)}
+
+	col := 0
+	cols := make([]int, 0, 16)
+	for shapeIdx, n := range region.counts {
+		if n == 0 || len(allMasks[shapeIdx]) == 0 {
+			continue
+		}
+		ec.matrix.SetMultiplicity(col, n)
+		for mi := range allMasks[shapeIdx] {
+			m := &allMasks[shapeIdx][mi]
+			for r := 0; r+m.maxRow < region.height; r++ {
+				for c := 0; c+m.maxCol < region.width; c++ {
+					p := placement{shape: shapeIdx, mask: m, row: r, col: c}
+					cols = append(cols[:0], col)
+					p.cells(func(r, c int) {
+						cols = append(cols, shapes+r*region.width+c)
+					})
+					ec.matrix.AddRow(cols...)
+					ec.placements = append(ec.placements, p)
+				}
+			}
+		}
+		col++
+	}
+	return ec
+}
+
+// packings yields every distinct way to place all the presents, with the
+// copies of each shape in increasing placement order. The slice is reused
+// between packings.
+func (ec *exactCover) packings() iter.Seq[[]placement] {
+	return func(yield func([]placement) bool) {
+		var out []placement
+		for rows := range ec.matrix.Solutions() {
+			slices.Sort(rows)
+			out = out[:0]
+			for _, row := range rows {
+				out = append(out, ec.placements[row])
+			}
+			if !yield(out) {
+				return
+			}
+		}
+	}
+}
+
+// canFitExact decides a region by exact cover alone
+func canFitExact(allMasks [][]ShapeMask, region Region) bool {
+	_, ok := newExactCover(allMasks, region).matrix.First()
+	return ok
+}
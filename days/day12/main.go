@@ -0,0 +1,937 @@
+package day12
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"aoc2025/internal/aoc"
+)
+
+// Shape represents a present shape as a list of (row, col) offsets
+type Shape []struct{ r, c int }
+
+// ShapeMask is a precomputed bitmask representation of a shape
+type ShapeMask struct {
+	rowMasks  []uint64 // mask for each row (relative to top of shape)
+	bitShifts []int    // precomputed bit positions for blocked mask
+	maxRow    int      // maximum row offset
+	maxCol    int      // maximum column offset
+}
+
+// Generate all unique orientations of a shape, in a fixed order so that
+// searches over them are repeatable
+func allOrientations(s Shape) []Shape {
+	seen := make(map[string]bool)
+	var result []Shape
+
+	current := s
+	for flip := 0; flip < 2; flip++ {
+		for rot := 0; rot < 4; rot++ {
+			normalized := normalize(current)
+			key := shapeKey(normalized)
+			if !seen[key] {
+				seen[key] = true
+				result = append(result, normalized)
+			}
+			current = rotate90(current)
+		}
+		current = flipShape(s)
+	}
+	return result
+}
+
+func rotate90(s Shape) Shape {
+	result := make(Shape, len(s))
+	for i, p := range s {
+		result[i] = struct{ r, c int }{p.c, -p.r}
+	}
+	return result
+}
+
+func flipShape(s Shape) Shape {
+	result := make(Shape, len(s))
+	for i, p := range s {
+		result[i] = struct{ r, c int }{p.r, -p.c}
+	}
+	return result
+}
+
+func normalize(s Shape) Shape {
+	if len(s) == 0 {
+		return s
+	}
+	minR, minC := s[0].r, s[0].c
+	for _, p := range s {
+		minR = min(minR, p.r)
+		minC = min(minC, p.c)
+	}
+	result := make(Shape, len(s))
+	for i, p := range s {
+		result[i] = struct{ r, c int }{p.r - minR, p.c - minC}
+	}
+	return result
+}
+
+func shapeKey(s Shape) string {
+	points := make([]string, len(s))
+	for i, p := range s {
+		points[i] = fmt.Sprintf("%d,%d", p.r, p.c)
+	}
+	slices.Sort(points)
+	return strings.Join(points, ";")
+}
+
+// Convert shape to bitmask form
+func shapeToMask(s Shape) ShapeMask {
+	if len(s) == 0 {
+		return ShapeMask{}
+	}
+
+	maxR, maxC := 0, 0
+	for _, p := range s {
+		maxR = max(maxR, p.r)
+		maxC = max(maxC, p.c)
+	}
+
+	rowMasks := make([]uint64, maxR+1)
+	for _, p := range s {
+		rowMasks[p.r] |= 1 << p.c
+	}
+
+	// Precompute bit positions for blocked mask calculation
+	// Each entry is (rowIndex << 8) | bitPos for efficient iteration
+	var bitShifts []int
+	for i, mask := range rowMasks {
+		for bit := mask; bit != 0; {
+			bitPos := bits.TrailingZeros64(bit)
+			bitShifts = append(bitShifts, (i<<8)|bitPos)
+			bit &= bit - 1
+		}
+	}
+
+	return ShapeMask{
+		rowMasks:  rowMasks,
+		bitShifts: bitShifts,
+		maxRow:    maxR,
+		maxCol:    maxC,
+	}
+}
+
+func parseShape(lines []string) Shape {
+	var shape Shape
+	for r, line := range lines {
+		for c, ch := range line {
+			if ch == '#' {
+				shape = append(shape, struct{ r, c int }{r, c})
+			}
+		}
+	}
+	return shape
+}
+
+// Region problem
+type Region struct {
+	width, height int
+	counts        []int
+}
+
+func isRegionLine(line string) bool {
+	return strings.Contains(line, "x") && strings.Contains(line, ": ")
+}
+
+// parseRegion parses a "WxH: n1 n2 ..." line; lineNo is 1-based
+func parseRegion(line string, lineNo int) (Region, error) {
+	dims, countStr, ok := strings.Cut(line, ": ")
+	if !ok {
+		return Region{}, aoc.ParseErrorf(12, lineNo, 0, "want \"WxH: counts\"")
+	}
+	wStr, hStr, ok := strings.Cut(dims, "x")
+	if !ok {
+		return Region{}, aoc.ParseErrorf(12, lineNo, 1, "region size %q is not WxH", dims)
+	}
+	width, err := aoc.Atoi(12, lineNo, 1, wStr)
+	if err != nil {
+		return Region{}, err
+	}
+	height, err := aoc.Atoi(12, lineNo, len(wStr)+2, hStr)
+	if err != nil {
+		return Region{}, err
+	}
+	if width <= 0 || height <= 0 {
+		return Region{}, aoc.ParseErrorf(12, lineNo, 1, "region %q has no area", dims)
+	}
+
+	fields := aoc.Fields(countStr)
+	counts := make([]int, len(fields))
+	for i, f := range fields {
+		col := len(dims) + 2 + f.Column
+		n, err := aoc.Atoi(12, lineNo, col, f.Text)
+		if err != nil {
+			return Region{}, err
+		}
+		if n < 0 {
+			return Region{}, aoc.ParseErrorf(12, lineNo, col, "negative count %d", n)
+		}
+		counts[i] = n
+	}
+	return Region{width: width, height: height, counts: counts}, nil
+}
+
+// parseInput returns every orientation of each shape, the regions, and the
+// number of cells in each shape
+func parseInput(lines []string) ([][]ShapeMask, []Region, []int, error) {
+	regionStart := -1
+	for i, line := range lines {
+		if isRegionLine(line) {
+			regionStart = i
+			break
+		}
+	}
+	if regionStart == -1 {
+		return nil, nil, nil, aoc.ParseErrorf(12, 0, 0, "no \"WxH: counts\" region lines")
+	}
+
+	shapeLines := lines[:regionStart]
+	baseShapes := []Shape{}
+	var currentShapeLines []string
+	for i, line := range shapeLines {
+		if header, ok := strings.CutSuffix(line, ":"); ok {
+			if len(currentShapeLines) > 0 {
+				baseShapes = append(baseShapes, parseShape(currentShapeLines))
+			}
+			currentShapeLines = nil
+			idx, err := aoc.Atoi(12, i+1, 1, header)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if idx != len(baseShapes) {
+				return nil, nil, nil, aoc.ParseErrorf(12, i+1, 1, "shape %d out of order, want %d", idx, len(baseShapes))
+			}
+		} else if line != "" {
+			if c := strings.IndexFunc(line, func(r rune) bool { return r != '#' && r != '.' }); c >= 0 {
+				return nil, nil, nil, aoc.ParseErrorf(12, i+1, c+1, "shape cell must be '#' or '.', got %q", line[c])
+			}
+			currentShapeLines = append(currentShapeLines, line)
+		}
+	}
+	if len(currentShapeLines) > 0 {
+		baseShapes = append(baseShapes, parseShape(currentShapeLines))
+	}
+
+	// Convert to masks for all orientations
+	allMasks := make([][]ShapeMask, len(baseShapes))
+	cells := make([]int, len(baseShapes))
+	for i, s := range baseShapes {
+		orientations := allOrientations(s)
+		allMasks[i] = make([]ShapeMask, len(orientations))
+		for j, o := range orientations {
+			allMasks[i][j] = shapeToMask(o)
+		}
+		cells[i] = len(s)
+	}
+
+	regionLines := lines[regionStart:]
+	regions := []Region{}
+	for i, line := range regionLines {
+		if line == "" {
+			continue
+		}
+		lineNo := regionStart + i + 1
+		region, err := parseRegion(line, lineNo)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(region.counts) > len(baseShapes) {
+			return nil, nil, nil, aoc.ParseErrorf(12, lineNo, 0, "%d counts but only %d shapes", len(region.counts), len(baseShapes))
+		}
+		regions = append(regions, region)
+	}
+
+	return allMasks, regions, cells, nil
+}
+
+// Grid for solving using row bitmasks. Each row takes words uint64s, with
+// column c in bit c%64 of word c/64, so narrow regions use one word per row.
+type Grid struct {
+	width, height int
+	words         int
+	rows          []uint64
+}
+
+func newGrid(w, h int) *Grid {
+	g := &Grid{}
+	g.reset(w, h)
+	return g
+}
+
+// wordsFor returns how many uint64s a row of width w needs
+func wordsFor(w int) int {
+	return max(1, (w+63)/64)
+}
+
+// row returns the words of row r
+func (g *Grid) row(r int) []uint64 {
+	return g.rows[r*g.words : (r+1)*g.words]
+}
+
+func (g *Grid) canPlace(m *ShapeMask, startR, startC int) bool {
+	if startR < 0 || startR+m.maxRow >= g.height || startC < 0 || startC+m.maxCol >= g.width {
+		return false
+	}
+	if g.words == 1 {
+		for i, mask := range m.rowMasks {
+			if g.rows[startR+i]&(mask<<startC) != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	w, off := startC/64, startC%64
+	for i, mask := range m.rowMasks {
+		row := g.row(startR + i)
+		if row[w]&(mask<<off) != 0 {
+			return false
+		}
+		if spill := mask >> (64 - off); spill != 0 && row[w+1]&spill != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Grid) place(m *ShapeMask, startR, startC int) {
+	if g.words == 1 {
+		for i, mask := range m.rowMasks {
+			g.rows[startR+i] |= mask << startC
+		}
+		return
+	}
+	w, off := startC/64, startC%64
+	for i, mask := range m.rowMasks {
+		row := g.row(startR + i)
+		row[w] |= mask << off
+		if spill := mask >> (64 - off); spill != 0 {
+			row[w+1] |= spill
+		}
+	}
+}
+
+func (g *Grid) remove(m *ShapeMask, startR, startC int) {
+	if g.words == 1 {
+		for i, mask := range m.rowMasks {
+			g.rows[startR+i] &^= mask << startC
+		}
+		return
+	}
+	w, off := startC/64, startC%64
+	for i, mask := range m.rowMasks {
+		row := g.row(startR + i)
+		row[w] &^= mask << off
+		if spill := mask >> (64 - off); spill != 0 {
+			row[w+1] &^= spill
+		}
+	}
+}
+
+func (g *Grid) firstEmpty() (int, int) {
+	for i, word := range g.rows {
+		if invWord := ^word; invWord != 0 {
+			r := i / g.words
+			c := (i%g.words)*64 + bits.TrailingZeros64(invWord)
+			if c < g.width {
+				return r, c
+			}
+		}
+	}
+	return -1, -1
+}
+
+func (g *Grid) setCell(r, c int) {
+	g.rows[r*g.words+c/64] |= 1 << (c % 64)
+}
+
+func (g *Grid) clearCell(r, c int) {
+	g.rows[r*g.words+c/64] &^= 1 << (c % 64)
+}
+
+// ShapeEntry for the shape list
+type ShapeEntry struct {
+	shapeIdx  int
+	cellCount int
+}
+
+// Solver for backtracking
+type Solver struct {
+	grid     *Grid
+	allMasks [][]ShapeMask
+	shapes   []ShapeEntry // presents to place, grouped by shape
+	stop     *atomic.Bool // abandons the search once set, if not nil
+
+	prune         pruneFlags
+	breakSymmetry bool
+	symShape      int   // shape of which some present must be placed canonically
+	symLastRow    int   // last row a canonical present of symShape can start on
+	symLeft       int   // presents of symShape still to place
+	symPlaced     int   // presents of symShape placed canonically
+	dead          []int // cells filled by fillPockets, as r*width+c
+	pocket        []int // scratch for fillPocket
+	stats         SolverStats
+}
+
+// newSolver returns a solver for shapes in g with the given pruning. It
+// reorders shapes so that shapes with the fewest copies come first, which
+// branch then tries first: they are the most constrained, and a shape with
+// many interchangeable copies is easiest to fit in around them.
+func newSolver(g *Grid, allMasks [][]ShapeMask, shapes []ShapeEntry, prune pruneFlags) *Solver {
+	copies := make(map[int]int)
+	for _, se := range shapes {
+		copies[se.shapeIdx]++
+	}
+	slices.SortFunc(shapes, func(a, b ShapeEntry) int {
+		return cmp.Or(cmp.Compare(copies[a.shapeIdx], copies[b.shapeIdx]), cmp.Compare(a.shapeIdx, b.shapeIdx))
+	})
+	s := &Solver{grid: g, allMasks: allMasks, shapes: shapes, prune: prune}
+	if prune&pruneSymmetry == 0 || len(shapes) == 0 {
+		return s
+	}
+	s.breakSymmetry = true
+	s.symShape = shapes[0].shapeIdx
+	s.symLeft = copies[s.symShape]
+	s.symLastRow = -1
+	for _, m := range allMasks[s.symShape] {
+		for r := 0; 2*r+m.maxRow <= g.height-1; r++ {
+			s.symLastRow = max(s.symLastRow, r)
+		}
+	}
+	return s
+}
+
+// solve reports whether s.shapes[idx:] can all be placed leaving at most
+// skipsLeft more cells empty
+func (s *Solver) solve(idx int, skipsLeft int) bool {
+	if idx == len(s.shapes) {
+		return true
+	}
+	if s.stop != nil && s.stop.Load() {
+		return false
+	}
+	return !s.branch(idx, skipsLeft, func(_ placement, idx, skipsLeft int) bool {
+		return !s.solve(idx, skipsLeft)
+	})
+}
+
+// branch generates the moves from the node where s.shapes[idx:] remain and
+// skipsLeft cells may still be left empty. The first empty cell is covered
+// in every way by each distinct remaining shape, then, budget permitting,
+// left empty. For each move f is called with the grid updated, the present
+// moved to s.shapes[idx] and the child's idx and skipsLeft; a skip passes
+// a placement with a nil mask. The move is undone when f returns, and
+// branch stops and returns false as soon as f does. Every search over
+// packings is built on this, so pruning and stats apply to all of them.
+func (s *Solver) branch(idx, skipsLeft int, f func(p placement, idx, skipsLeft int) bool) bool {
+	r, c := s.grid.firstEmpty()
+	if r < 0 {
+		return true
+	}
+	if s.symmetryDead(r) {
+		s.stats.SymmetryPrunes++
+		return true
+	}
+	s.stats.Nodes++
+
+	// Rotating a present to idx keeps the rest grouped by shape, so each
+	// shape is tried once, from the first present of its group
+	for i := idx; i < len(s.shapes); i++ {
+		if i > idx && s.shapes[i].shapeIdx == s.shapes[i-1].shapeIdx {
+			continue
+		}
+		se := s.shapes[i]
+		copy(s.shapes[idx+1:i+1], s.shapes[idx:i])
+		s.shapes[idx] = se
+		more := s.placeAt(idx, skipsLeft, r, c, f)
+		copy(s.shapes[idx:i], s.shapes[idx+1:i+1])
+		s.shapes[i] = se
+		if !more {
+			return false
+		}
+	}
+
+	if skipsLeft == 0 {
+		return true
+	}
+	s.grid.setCell(r, c)
+	s.stats.Skips++
+	more := f(placement{shape: -1, row: r, col: c}, idx, skipsLeft-1)
+	s.grid.clearCell(r, c)
+	return more
+}
+
+// placeAt is branch's moves for the present at s.shapes[idx]: every
+// orientation anchored at each of its cells in turn on (r, c)
+func (s *Solver) placeAt(idx, skipsLeft, r, c int, f func(p placement, idx, skipsLeft int) bool) bool {
+	shapeIdx := s.shapes[idx].shapeIdx
+	for mi := range s.allMasks[shapeIdx] {
+		m := &s.allMasks[shapeIdx][mi]
+		for _, shift := range m.bitShifts {
+			startR, startC := r-shift>>8, c-shift&0xFF
+			if !s.grid.canPlace(m, startR, startC) {
+				continue
+			}
+			ok, canon := s.symmetryAllows(idx, m, startR, startC)
+			if !ok {
+				s.stats.SymmetryPrunes++
+				continue
+			}
+			sym := s.breakSymmetry && shapeIdx == s.symShape
+			if sym {
+				s.symLeft--
+				if canon {
+					s.symPlaced++
+				}
+			}
+			s.grid.place(m, startR, startC)
+			s.stats.Placements++
+			mark := len(s.dead)
+			more := true
+			if dead := s.fillPockets(m, startR, startC, idx+1); dead > skipsLeft {
+				s.stats.DeadPrunes++
+			} else {
+				more = f(placement{shape: shapeIdx, mask: m, row: startR, col: startC}, idx+1, skipsLeft-dead)
+			}
+			s.unfill(mark)
+			s.grid.remove(m, startR, startC)
+			if sym {
+				s.symLeft++
+				if canon {
+					s.symPlaced--
+				}
+			}
+			if !more {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Greedy placement - try row by row, packing tightly
+// Uses bitmask operations to find valid positions faster
+func greedyPlace(g *Grid, allMasks [][]ShapeMask, shapes []ShapeEntry) bool {
+	if g.words > 1 {
+		return greedyPlaceWide(g, allMasks, shapes)
+	}
+	for _, se := range shapes {
+		placed := false
+		orientations := allMasks[se.shapeIdx]
+
+		// Try each orientation
+	orientLoop:
+		for mi := range orientations {
+			m := &orientations[mi]
+			maxStartR := g.height - m.maxRow - 1
+			maxStartC := g.width - m.maxCol - 1
+			if maxStartR < 0 || maxStartC < 0 {
+				continue
+			}
+			rowMasks := m.rowMasks
+			nRows := len(rowMasks)
+			validMask := uint64((1 << (maxStartC + 1)) - 1)
+			shifts := m.bitShifts
+			rows := g.rows
+			nShifts := len(shifts)
+
+			// Try positions row by row
+			for startR := 0; startR <= maxStartR; startR++ {
+				// Compute combined blocked mask using precomputed bit shifts
+				// Unroll for common cell counts (5, 6, 7)
+				var blocked uint64
+				switch nShifts {
+				case 5:
+					s0, s1, s2, s3, s4 := shifts[0], shifts[1], shifts[2], shifts[3], shifts[4]
+					blocked = rows[startR+(s0>>8)]>>(s0&0xFF) |
+						rows[startR+(s1>>8)]>>(s1&0xFF) |
+						rows[startR+(s2>>8)]>>(s2&0xFF) |
+						rows[startR+(s3>>8)]>>(s3&0xFF) |
+						rows[startR+(s4>>8)]>>(s4&0xFF)
+				case 6:
+					s0, s1, s2, s3, s4, s5 := shifts[0], shifts[1], shifts[2], shifts[3], shifts[4], shifts[5]
+					blocked = rows[startR+(s0>>8)]>>(s0&0xFF) |
+						rows[startR+(s1>>8)]>>(s1&0xFF) |
+						rows[startR+(s2>>8)]>>(s2&0xFF) |
+						rows[startR+(s3>>8)]>>(s3&0xFF) |
+						rows[startR+(s4>>8)]>>(s4&0xFF) |
+						rows[startR+(s5>>8)]>>(s5&0xFF)
+				case 7:
+					s0, s1, s2, s3, s4, s5, s6 := shifts[0], shifts[1], shifts[2], shifts[3], shifts[4], shifts[5], shifts[6]
+					blocked = rows[startR+(s0>>8)]>>(s0&0xFF) |
+						rows[startR+(s1>>8)]>>(s1&0xFF) |
+						rows[startR+(s2>>8)]>>(s2&0xFF) |
+						rows[startR+(s3>>8)]>>(s3&0xFF) |
+						rows[startR+(s4>>8)]>>(s4&0xFF) |
+						rows[startR+(s5>>8)]>>(s5&0xFF) |
+						rows[startR+(s6>>8)]>>(s6&0xFF)
+				default:
+					for _, shift := range shifts {
+						blocked |= rows[startR+(shift>>8)] >> (shift & 0xFF)
+					}
+				}
+
+				// Find available positions
+				available := (^blocked) & validMask
+				if available == 0 {
+					continue
+				}
+
+				// Try first available position - blocked mask should be exact
+				startC := bits.TrailingZeros64(available)
+
+				// Place directly without verification (blocked mask is exact)
+				for i := 0; i < nRows; i++ {
+					g.rows[startR+i] |= rowMasks[i] << startC
+				}
+				placed = true
+				break orientLoop
+			}
+		}
+		if !placed {
+			return false
+		}
+	}
+	return true
+}
+
+// greedyPlaceWide is greedyPlace for rows of several words. The blocked
+// mask of each row is built a word at a time, shifting the grid row right
+// across word boundaries.
+func greedyPlaceWide(g *Grid, allMasks [][]ShapeMask, shapes []ShapeEntry) bool {
+	blocked := make([]uint64, g.words)
+	for _, se := range shapes {
+		placed := false
+		orientations := allMasks[se.shapeIdx]
+
+	orientLoop:
+		for mi := range orientations {
+			m := &orientations[mi]
+			maxStartR := g.height - m.maxRow - 1
+			maxStartC := g.width - m.maxCol - 1
+			if maxStartR < 0 || maxStartC < 0 {
+				continue
+			}
+
+			for startR := 0; startR <= maxStartR; startR++ {
+				clear(blocked)
+				for _, shift := range m.bitShifts {
+					row := g.row(startR + shift>>8)
+					dc := shift & 0xFF
+					for w := range blocked {
+						v := row[w] >> dc
+						if w+1 < len(row) {
+							v |= row[w+1] << (64 - dc)
+						}
+						blocked[w] |= v
+					}
+				}
+
+				for w := 0; w*64 <= maxStartC; w++ {
+					available := ^blocked[w]
+					if n := maxStartC - w*64 + 1; n < 64 {
+						available &= 1<<n - 1
+					}
+					if available == 0 {
+						continue
+					}
+					g.place(m, startR, w*64+bits.TrailingZeros64(available))
+					placed = true
+					break orientLoop
+				}
+			}
+		}
+		if !placed {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Grid) reset(w, h int) {
+	g.width = w
+	g.height = h
+	g.words = wordsFor(w)
+	n := h * g.words
+	if cap(g.rows) >= n {
+		g.rows = g.rows[:n]
+		clear(g.rows)
+	} else {
+		g.rows = make([]uint64, n)
+	}
+}
+
+// regionShapes lists region's presents in shapes, reusing its storage, and
+// returns the cells they cover
+func regionShapes(allMasks [][]ShapeMask, region Region, cells []int, shapes []ShapeEntry) ([]ShapeEntry, int) {
+	shapes = shapes[:0]
+	totalCells := 0
+	for shapeIdx, count := range region.counts {
+		if count > 0 && len(allMasks[shapeIdx]) > 0 {
+			for range count {
+				shapes = append(shapes, ShapeEntry{shapeIdx: shapeIdx, cellCount: cells[shapeIdx]})
+			}
+			totalCells += count * cells[shapeIdx]
+		}
+	}
+	return shapes, totalCells
+}
+
+// quickFit settles a region by area or greedy placement when it can
+func quickFit(allMasks [][]ShapeMask, region Region, shapes []ShapeEntry, totalCells int, g *Grid) (fit, settled bool) {
+	if len(shapes) == 0 {
+		return true, true
+	}
+	if totalCells > region.width*region.height {
+		return false, true
+	}
+	g.reset(region.width, region.height)
+	if greedyPlace(g, allMasks, shapes) {
+		return true, true
+	}
+	return false, false
+}
+
+func canFit(allMasks [][]ShapeMask, region Region, cells []int, shapes []ShapeEntry, g *Grid) bool {
+	return canFitWorkers(allMasks, region, cells, shapes, g, 1)
+}
+
+// canFitWorkers is canFit with the backtracking shared among workers
+func canFitWorkers(allMasks [][]ShapeMask, region Region, cells []int, shapes []ShapeEntry, g *Grid, workers int) bool {
+	fit, _ := canFitStats(allMasks, region, cells, shapes, g, workers, pruneAll)
+	return fit
+}
+
+// canFitStats is canFitWorkers with a choice of pruning, also returning
+// the work the backtracking did
+func canFitStats(allMasks [][]ShapeMask, region Region, cells []int, shapes []ShapeEntry, g *Grid, workers int, prune pruneFlags) (bool, SolverStats) {
+	shapes, totalCells := regionShapes(allMasks, region, cells, shapes)
+	if fit, settled := quickFit(allMasks, region, shapes, totalCells, g); settled {
+		return fit, SolverStats{}
+	}
+	return searchFit(allMasks, region, shapes, totalCells, g, workers, prune)
+}
+
+// searchFit decides a region quickFit left unsettled by backtracking over
+// the presents in shapes, which cover totalCells cells
+func searchFit(allMasks [][]ShapeMask, region Region, shapes []ShapeEntry, totalCells int, g *Grid, workers int, prune pruneFlags) (bool, SolverStats) {
+	g.reset(region.width, region.height)
+	solver := newSolver(g, allMasks, shapes, prune)
+	skipsAllowed := region.width*region.height - totalCells
+	var fit bool
+	if workers > 1 {
+		fit = solver.solveParallel(skipsAllowed, workers)
+	} else {
+		fit = solver.solve(0, skipsAllowed)
+	}
+	return fit, solver.stats
+}
+
+func part1Sequential(lines []string) (int, error) {
+	allMasks, regions, cells, err := parseInput(lines)
+	if err != nil {
+		return 0, err
+	}
+	shapes := make([]ShapeEntry, 0, 300)
+	g := &Grid{rows: make([]uint64, 64)}
+	count := 0
+	for _, r := range regions {
+		if canFit(allMasks, r, cells, shapes, g) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// part1Arithmetic uses pure arithmetic check like the Rust solution.
+// It only counts the 3x3 blocks, so it is wrong whenever presents would
+// have to interlock; part1 trusts it only when it says yes.
+func part1Arithmetic(lines []string) (int, error) {
+	// Find where regions start (skip shape definitions)
+	regionStart := 0
+	for i, line := range lines {
+		if isRegionLine(line) {
+			regionStart = i
+			break
+		}
+	}
+
+	count := 0
+	for i, line := range lines[regionStart:] {
+		if line == "" {
+			continue
+		}
+		region, err := parseRegion(line, regionStart+i+1)
+		if err != nil {
+			return 0, err
+		}
+		totalShapes := 0
+		for _, n := range region.counts {
+			totalShapes += n
+		}
+
+		// Capacity check: (w/3) * (h/3) >= totalShapes
+		if (region.width/3)*(region.height/3) >= totalShapes {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// blockSide returns the side of the smallest square block that every shape
+// fits in, in some orientation
+func blockSide(allMasks [][]ShapeMask) int {
+	block := 1
+	for _, orientations := range allMasks {
+		side := -1
+		for _, m := range orientations {
+			if s := max(m.maxRow, m.maxCol) + 1; side < 0 || s < side {
+				side = s
+			}
+		}
+		block = max(block, side)
+	}
+	return block
+}
+
+// decide reports whether region's presents fit. Two cheap checks settle
+// almost every region: too few cells in total rules a packing out, and
+// enough disjoint block x block squares for one present each guarantees
+// one. Only when they disagree does it search, first greedily and then
+// exhaustively with exact cover.
+func decide(allMasks [][]ShapeMask, region Region, cells []int, block int, g *Grid, shapes []ShapeEntry) bool {
+	need, presents := 0, 0
+	for shapeIdx, n := range region.counts {
+		need += n * cells[shapeIdx]
+		if cells[shapeIdx] > 0 {
+			presents += n
+		}
+	}
+	if need > region.width*region.height {
+		return false
+	}
+	if (region.width/block)*(region.height/block) >= presents {
+		return true
+	}
+
+	shapes = shapes[:0]
+	for shapeIdx, n := range region.counts {
+		for range n {
+			if cells[shapeIdx] > 0 {
+				shapes = append(shapes, ShapeEntry{shapeIdx: shapeIdx, cellCount: cells[shapeIdx]})
+			}
+		}
+	}
+	g.reset(region.width, region.height)
+	if greedyPlace(g, allMasks, shapes) {
+		return true
+	}
+	return canFitExact(allMasks, region)
+}
+
+func part1(lines []string) (int, error) {
+	allMasks, regions, cells, err := parseInput(lines)
+	if err != nil {
+		return 0, err
+	}
+	block := blockSide(allMasks)
+	shapes := make([]ShapeEntry, 0, 300)
+	g := &Grid{rows: make([]uint64, 64)}
+	count := 0
+	for _, r := range regions {
+		if decide(allMasks, r, cells, block, g, shapes) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// part1Backtracking settles the easy regions in parallel, then gives each
+// region that needs a search all the workers in turn, so that a single
+// hard region does not run on one core
+func part1Backtracking(lines []string) (int, error) {
+	allMasks, regions, cells, err := parseInput(lines)
+	if err != nil {
+		return 0, err
+	}
+
+	numWorkers := runtime.NumCPU()
+	var wg sync.WaitGroup
+	var count atomic.Int64
+	var idx atomic.Int64
+	n := int64(len(regions))
+	hard := make([]bool, len(regions))
+
+	// Start worker pool
+	for range numWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shapes := make([]ShapeEntry, 0, 300)
+			g := &Grid{rows: make([]uint64, 64)}
+			localCount := 0
+			for {
+				i := idx.Add(1) - 1
+				if i >= n {
+					break
+				}
+				var totalCells int
+				shapes, totalCells = regionShapes(allMasks, regions[i], cells, shapes)
+				fit, settled := quickFit(allMasks, regions[i], shapes, totalCells, g)
+				if fit {
+					localCount++
+				}
+				hard[i] = !settled
+			}
+			count.Add(int64(localCount))
+		}()
+	}
+	wg.Wait()
+
+	// quickFit has already run on the hard regions, so go straight to the search
+	g := &Grid{}
+	var shapes []ShapeEntry
+	for i, r := range regions {
+		if !hard[i] {
+			continue
+		}
+		var totalCells int
+		shapes, totalCells = regionShapes(allMasks, r, cells, shapes)
+		if fit, _ := searchFit(allMasks, r, shapes, totalCells, g, numWorkers, pruneAll); fit {
+			count.Add(1)
+		}
+	}
+	return int(count.Load()), nil
+}
+
+func part2(lines []string) (int, error) {
+	return 0, nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{
+		Number: 12,
+		Part1:  part1,
+		Variants: []aoc.Variant{
+			{Name: "backtracking", Part: 1, Solve: part1Backtracking},
+		},
+		Tools: []aoc.Tool{
+			{Name: "stats", Usage: "[-workers n] [-prune none|dead|symmetry|all] [region...]", Run: statsTool},
+			{Name: "packings", Usage: "[-sym] [-draw] [-n limit] region", Run: packingsTool},
+			{Name: "maxpack", Usage: "[-area] [-draw] region...", Run: maxpackTool},
+			{Name: "sat", Usage: "[region...]", Run: satTool},
+			{Name: "dimacs", Usage: "[-o file] region", Run: dimacsTool},
+		},
+	})
+}
@@ -0,0 +1,878 @@
+package day12
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"aoc2025/internal/aoc"
+	"aoc2025/internal/sat"
+)
+
+var example = `0:
+###
+##.
+##.
+
+1:
+###
+##.
+.##
+
+2:
+.##
+###
+##.
+
+3:
+##.
+###
+##.
+
+4:
+###
+#..
+###
+
+5:
+###
+.#.
+###
+
+4x4: 0 0 0 0 2 0
+12x5: 1 0 1 0 2 2
+12x5: 1 0 1 0 3 2`
+
+func TestPart1(t *testing.T) {
+	lines := strings.Split(example, "\n")
+	got, err := part1(lines)
+	want := 2
+	if err != nil || got != want {
+		t.Errorf("part1() = %d, %v; want %d", got, err, want)
+	}
+}
+
+func TestDecide(t *testing.T) {
+	allMasks, _, cells, err := parseInput(strings.Split(example, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := blockSide(allMasks)
+	if block != 3 {
+		t.Fatalf("blockSide() = %d, want 3", block)
+	}
+	tests := []struct {
+		name   string
+		region Region
+		want   bool
+	}{
+		{"too few cells", Region{width: 4, height: 3, counts: []int{2}}, false},
+		{"one block each", Region{width: 9, height: 6, counts: []int{1, 1, 1, 1, 1, 1}}, true},
+		{"interlocked", Region{width: 4, height: 4, counts: []int{0, 0, 0, 0, 2}}, true},
+		{"no packing", Region{width: 12, height: 5, counts: []int{1, 0, 1, 0, 3, 2}}, false},
+		{"wider than a word", Region{width: 70, height: 3, counts: []int{23}}, true},
+	}
+	g := &Grid{}
+	for _, tc := range tests {
+		if got := decide(allMasks, tc.region, cells, block, g, nil); got != tc.want {
+			t.Errorf("%s: decide() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestWideGrid(t *testing.T) {
+	dominoes := [][]ShapeMask{{
+		shapeToMask(Shape{{0, 0}, {0, 1}}),
+		shapeToMask(Shape{{0, 0}, {1, 0}}),
+	}}
+	for _, width := range []int{63, 64, 65, 200} {
+		t.Run(strconv.Itoa(width), func(t *testing.T) {
+			// Greedy row filling must cover every cell exactly once
+			g := newGrid(width, 4)
+			shapes := make([]ShapeEntry, 2*width)
+			if !greedyPlace(g, dominoes, shapes) {
+				t.Fatal("greedyPlace() = false, want true")
+			}
+			if r, c := g.firstEmpty(); r >= 0 {
+				t.Errorf("cell %d,%d left empty", r, c)
+			}
+			set := 0
+			for _, word := range g.rows {
+				set += bits.OnesCount64(word)
+			}
+			if set != 4*width {
+				t.Errorf("%d cells set, want %d", set, 4*width)
+			}
+
+			// A horizontal domino across the last two columns, and so across
+			// a word boundary for width 65
+			g.reset(width, 1)
+			m := &dominoes[0][0]
+			if g.canPlace(m, 0, width-1) {
+				t.Error("canPlace() past the right edge = true")
+			}
+			g.setCell(0, width-1)
+			if g.canPlace(m, 0, width-2) {
+				t.Error("canPlace() over a filled cell = true")
+			}
+			g.clearCell(0, width-1)
+			g.place(m, 0, width-2)
+			if r, c := g.firstEmpty(); r != 0 || c != 0 {
+				t.Errorf("firstEmpty() = %d,%d, want 0,0", r, c)
+			}
+			g.place(m, 0, 0)
+			if _, c := g.firstEmpty(); c != 2 {
+				t.Errorf("firstEmpty() column = %d, want 2", c)
+			}
+			g.remove(m, 0, width-2)
+			if !g.canPlace(m, 0, width-2) {
+				t.Error("canPlace() after remove = false")
+			}
+
+			// Odd widths force the backtracker to stand dominoes upright
+			region := Region{width: width, height: 2, counts: []int{width}}
+			if !canFit(dominoes, region, []int{2}, nil, &Grid{}) {
+				t.Error("canFit() = false, want true")
+			}
+			region.counts[0]++
+			if canFit(dominoes, region, []int{2}, nil, &Grid{}) {
+				t.Error("canFit() with one domino too many = true")
+			}
+		})
+	}
+}
+
+// mixed has an L tetromino before a P pentomino, so the last shape's size
+// overstates the tetromino's
+var mixed = `0:
+#.
+#.
+##
+
+1:
+##
+##
+#.
+
+4x2: 2 0
+3x3: 1 1
+5x2: 0 2
+3x3: 0 2
+4x3: 2 1
+4x4: 4 0`
+
+func TestMixedSizes(t *testing.T) {
+	allMasks, regions, cells, err := parseInput(strings.Split(mixed, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(cells, []int{4, 5}) {
+		t.Fatalf("parseInput() cells = %v, want [4 5]", cells)
+	}
+	block := blockSide(allMasks)
+	for i, want := range []bool{true, true, true, false, false, true} {
+		if got := canFit(allMasks, regions[i], cells, nil, &Grid{}); got != want {
+			t.Errorf("region %d: canFit() = %v, want %v", i, got, want)
+		}
+		if got := decide(allMasks, regions[i], cells, block, &Grid{}, nil); got != want {
+			t.Errorf("region %d: decide() = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestCanFitExact(t *testing.T) {
+	allMasks, regions, _, err := parseInput(strings.Split(example, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []bool{true, true, false} {
+		if got := canFitExact(allMasks, regions[i]); got != want {
+			t.Errorf("region %d: canFitExact() = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestPackings(t *testing.T) {
+	allMasks, _, _, err := parseInput(strings.Split(example, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	region := Region{width: 5, height: 4, counts: []int{0, 0, 0, 0, 2}}
+	ec := newExactCover(allMasks, region)
+
+	// Two copies of one shape: count unordered pairs of disjoint placements
+	disjoint := func(a, b placement) bool {
+		cells := make(map[[2]int]bool)
+		a.cells(func(r, c int) { cells[[2]int{r, c}] = true })
+		ok := true
+		b.cells(func(r, c int) { ok = ok && !cells[[2]int{r, c}] })
+		return ok
+	}
+	want := 0
+	for i, a := range ec.placements {
+		for _, b := range ec.placements[i+1:] {
+			if disjoint(a, b) {
+				want++
+			}
+		}
+	}
+	if want == 0 {
+		t.Fatal("test region admits no packing")
+	}
+
+	got := 0
+	seen := make(map[[2]placement]bool)
+	for ps := range ec.packings() {
+		if len(ps) != 2 || !disjoint(ps[0], ps[1]) {
+			t.Fatalf("packing %v is not two disjoint presents", ps)
+		}
+		key := [2]placement{ps[0], ps[1]}
+		if seen[key] {
+			t.Fatalf("packing %v yielded twice", ps)
+		}
+		seen[key] = true
+		got++
+	}
+	if got != want {
+		t.Errorf("packings() yielded %d packings, want %d", got, want)
+	}
+}
+
+// packingsCount runs the packings tool and returns the count it reports
+func packingsCount(t *testing.T, lines []string, args ...string) int {
+	t.Helper()
+	var out bytes.Buffer
+	if err := packingsTool(lines, args, &out); err != nil {
+		t.Fatalf("packings %v: %v", args, err)
+	}
+	report := strings.Split(strings.TrimSpace(out.String()), "\n")
+	fields := strings.Fields(report[len(report)-1])
+	n, err := strconv.Atoi(fields[len(fields)-2])
+	if err != nil {
+		t.Fatalf("packings %v: bad report %q", args, out.String())
+	}
+	return n
+}
+
+func TestPackingsTool(t *testing.T) {
+	lines := strings.Split("0:\n##\n\n3x2: 3\n4x2: 4\n4x4: 8\n3x3: 4", "\n")
+	dominoes, regions, cells, err := parseInput(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		all, distinct int
+	}{
+		{3, 2},
+		{5, 4},
+		{36, 0}, // distinct checked by Burnside below
+		{18, 0}, // one cell left empty
+	}
+	for i, tc := range tests {
+		region, arg := regions[i], strconv.Itoa(i+1)
+		if got := packingsCount(t, lines, arg); got != tc.all {
+			t.Errorf("%dx%d: %d packings, want %d", region.width, region.height, got, tc.all)
+		}
+
+		// Burnside: the number of orbits is the mean number of packings
+		// each symmetry fixes
+		syms := rectSymmetries(region.width, region.height)
+		fixed := 0
+		for ps := range enumeratePackings(dominoes, region, cells, false) {
+			key := packingKey(ps, region.width, region.height, syms[0], nil)
+			for _, sym := range syms {
+				if slices.Equal(packingKey(ps, region.width, region.height, sym, nil), key) {
+					fixed++
+				}
+			}
+		}
+		want := fixed / len(syms)
+		if tc.distinct != 0 && want != tc.distinct {
+			t.Fatalf("%dx%d: Burnside gives %d orbits, want %d", region.width, region.height, want, tc.distinct)
+		}
+		if got := packingsCount(t, lines, "-sym", arg); got != want {
+			t.Errorf("%dx%d: %d packings modulo symmetry, want %d", region.width, region.height, got, want)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := packingsTool(lines, []string{"-draw", "-n", "2", "1"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "packing 1:\nAAB\nCCB\n\npacking 2:\nABB\nACC\n\nregion 1 3x2: 2 packings\n"
+	if out.String() != want {
+		t.Errorf("packings -draw -n 2 =\n%s\nwant\n%s", out.String(), want)
+	}
+	for _, args := range [][]string{nil, {"1", "2"}, {"5"}} {
+		if err := packingsTool(lines, args, io.Discard); err == nil {
+			t.Errorf("packings %v succeeded, want error", args)
+		}
+	}
+}
+
+func TestEnumeratePackings(t *testing.T) {
+	allMasks, regions, cells, err := parseInput(strings.Split(mixed, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	region := regions[5] // four L tetrominoes in 4x4
+	n := 0
+	for ps := range enumeratePackings(allMasks, region, cells, false) {
+		covered := 0
+		for _, p := range ps {
+			covered += cells[p.shape]
+		}
+		if len(ps) != 4 || covered != 16 {
+			t.Fatalf("packing %v does not place four tetrominoes", ps)
+		}
+		n++
+	}
+	want := 0
+	for range newExactCover(allMasks, region).packings() {
+		want++
+	}
+	if n == 0 || n != want {
+		t.Errorf("enumeratePackings() yielded %d packings, exact cover finds %d", n, want)
+	}
+	if distinct := packingsCount(t, strings.Split(mixed, "\n"), "-sym", "6"); distinct == 0 || distinct*8 < n {
+		t.Errorf("%d packings modulo symmetry, want between %d and %d", distinct, (n+7)/8, n)
+	}
+
+	// Each shape may only be tried once per cell, however many copies are left
+	allMasks, _, cells, err = parseInput(strings.Split(example, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	region = Region{width: 9, height: 3, counts: []int{1, 0, 0, 0, 2, 0}}
+	want = 0
+	for range newExactCover(allMasks, region).packings() {
+		want++
+	}
+	got := 0
+	for range enumeratePackings(allMasks, region, cells, false) {
+		got++
+	}
+	if want == 0 || got != want {
+		t.Errorf("enumeratePackings() of three presents yielded %d, exact cover finds %d", got, want)
+	}
+}
+
+func TestMaxPacking(t *testing.T) {
+	mixedMasks, mixedRegions, mixedCells, err := parseInput(strings.Split(mixed, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exampleMasks, exampleRegions, exampleCells, err := parseInput(strings.Split(example, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		allMasks [][]ShapeMask
+		region   Region
+		cells    []int
+		byArea   bool
+		value    int
+		omitted  []int
+	}{
+		{"fits", mixedMasks, mixedRegions[1], mixedCells, false, 2, []int{0, 0}},
+		{"two pentominoes in 3x3", mixedMasks, mixedRegions[3], mixedCells, false, 1, []int{0, 1}},
+		{"13 cells in 4x3 by count", mixedMasks, mixedRegions[4], mixedCells, false, 2, nil},
+		{"13 cells in 4x3 by area", mixedMasks, mixedRegions[4], mixedCells, true, 9, []int{1, 0}},
+		{"example region 3", exampleMasks, exampleRegions[2], exampleCells, false, 6, nil},
+	}
+	for _, tc := range tests {
+		got := maxPacking(tc.allMasks, tc.region, tc.cells, tc.byArea)
+		if got.value != tc.value || (tc.omitted != nil && !slices.Equal(got.omitted, tc.omitted)) {
+			t.Errorf("%s: maxPacking() = %d omitting %v, want %d omitting %v", tc.name, got.value, got.omitted, tc.value, tc.omitted)
+		}
+
+		// The placements must be disjoint and account for everything not omitted
+		g := newGrid(tc.region.width, tc.region.height)
+		placed := make([]int, len(tc.region.counts))
+		value := 0
+		for _, p := range got.placed {
+			if !g.canPlace(p.mask, p.row, p.col) {
+				t.Fatalf("%s: placement %v overlaps another", tc.name, p)
+			}
+			g.place(p.mask, p.row, p.col)
+			placed[p.shape]++
+			value++
+			if tc.byArea {
+				value += tc.cells[p.shape] - 1
+			}
+		}
+		for shapeIdx, n := range tc.region.counts {
+			if placed[shapeIdx]+got.omitted[shapeIdx] != n {
+				t.Errorf("%s: shape %d placed %d and omitted %d, want %d in all", tc.name, shapeIdx, placed[shapeIdx], got.omitted[shapeIdx], n)
+			}
+		}
+		if value != got.value {
+			t.Errorf("%s: placements are worth %d, value = %d", tc.name, value, got.value)
+		}
+	}
+}
+
+func TestMaxpackTool(t *testing.T) {
+	lines := strings.Split(mixed, "\n")
+	var out bytes.Buffer
+	if err := maxpackTool(lines, []string{"-draw", "4", "5"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "region 4 3x3: 1 of 2 presents, omitting 1 of shape 1\nAA.\nAA.\nA..\n" +
+		"region 5 4x3: 2 of 3 presents, omitting 1 of shape 0\nAAB.\nAAB.\nA.BB\n"
+	if out.String() != want {
+		t.Errorf("maxpack -draw 4 5 =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := maxpackTool(lines, []string{"-area", "2", "5"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	want = "region 2 3x3: 9 of 9 cells, omitting nothing\nregion 5 4x3: 9 of 13 cells, omitting 1 of shape 0\n"
+	if out.String() != want {
+		t.Errorf("maxpack -area 2 5 = %q, want %q", out.String(), want)
+	}
+	if err := maxpackTool(lines, nil, io.Discard); err == nil {
+		t.Error("maxpack without a region succeeded, want error")
+	}
+}
+
+func TestCanFitSAT(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  []bool
+	}{
+		// Refuting the third example region takes CDCL about 40 seconds
+		// even with the symmetry clause
+		{example, []bool{true, true}},
+		{mixed, []bool{true, true, true, false, false, true}},
+	} {
+		allMasks, regions, _, err := parseInput(strings.Split(tc.input, "\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, want := range tc.want {
+			if got := canFitSAT(allMasks, regions[i]); got != want {
+				t.Errorf("region %d %dx%d: canFitSAT() = %v, want %v", i, regions[i].width, regions[i].height, got, want)
+			}
+		}
+	}
+}
+
+func TestSATTool(t *testing.T) {
+	lines := strings.Split(mixed, "\n")
+	var out bytes.Buffer
+	if err := satTool(lines, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.HasSuffix(got, "4 of 6 regions fit\n") {
+		t.Errorf("sat output %q, want 4 of 6 regions to fit", got)
+	}
+
+	out.Reset()
+	if err := satTool(strings.Split(example, "\n"), []string{"1", "2"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "region 1 4x4: fit true without search\nregion 2 12x5: fit true in ") ||
+		!strings.HasSuffix(got, "2 of 2 regions fit\n") {
+		t.Errorf("sat for regions 1 and 2 = %q", got)
+	}
+	if err := satTool(lines, []string{"7"}, io.Discard); err == nil {
+		t.Error("sat on region 7 of 6 succeeded, want error")
+	}
+}
+
+func TestDimacsTool(t *testing.T) {
+	lines := strings.Split(example, "\n")
+	var out bytes.Buffer
+	if err := dimacsTool(lines, []string{"2"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	f, err := sat.ParseDIMACS(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sat.New(f).Solve() {
+		t.Error("dimacs 2 encodes an unsatisfiable formula, want satisfiable")
+	}
+
+	path := filepath.Join(t.TempDir(), "region2.cnf")
+	out.Reset()
+	if err := dimacsTool(lines, []string{"-o", path, "2"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := "region 2 12x5: wrote " + path + "\n"; out.String() != want {
+		t.Errorf("dimacs -o = %q, want %q", out.String(), want)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var direct bytes.Buffer
+	dimacsTool(lines, []string{"2"}, &direct)
+	if !bytes.Equal(data, direct.Bytes()) {
+		t.Error("dimacs -o wrote a different encoding than stdout")
+	}
+	if err := dimacsTool(lines, nil, io.Discard); err == nil {
+		t.Error("dimacs without a region succeeded, want error")
+	}
+}
+
+func TestRegionCNF(t *testing.T) {
+	allMasks, regions, _, err := parseInput(strings.Split(example, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	region := regions[1]
+	f, placements := regionCNF(allMasks, region)
+	s := sat.New(f)
+	if !s.Solve() {
+		t.Fatal("Solve() = false, want true")
+	}
+
+	// The model's placements must be disjoint and match the counts
+	g := newGrid(region.width, region.height)
+	placed := make([]int, len(region.counts))
+	for i, p := range placements {
+		if !s.Model()[i+1] {
+			continue
+		}
+		if !g.canPlace(p.mask, p.row, p.col) {
+			t.Fatalf("placement %v overlaps another", p)
+		}
+		g.place(p.mask, p.row, p.col)
+		placed[p.shape]++
+	}
+	if !slices.Equal(placed, region.counts) {
+		t.Errorf("model places %v, want %v", placed, region.counts)
+	}
+
+	var buf bytes.Buffer
+	if err := writeRegionDIMACS(&buf, allMasks, region); err != nil {
+		t.Fatal(err)
+	}
+	back, err := sat.ParseDIMACS(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Vars != f.Vars || len(back.Clauses) != len(f.Clauses) {
+		t.Errorf("DIMACS round trip has %d vars and %d clauses, want %d and %d", back.Vars, len(back.Clauses), f.Vars, len(f.Clauses))
+	}
+}
+
+func TestCanFitWorkers(t *testing.T) {
+	allMasks, _, cells, err := parseInput(strings.Split(mixed, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fits := 0
+	for w := 3; w <= 5; w++ {
+		for h := 3; h <= w; h++ {
+			for l := range 4 {
+				for p := range 4 {
+					region := Region{width: w, height: h, counts: []int{l, p}}
+					want := canFitExact(allMasks, region)
+					for _, workers := range []int{1, 2, 4} {
+						if got := canFitWorkers(allMasks, region, cells, nil, &Grid{}, workers); got != want {
+							t.Errorf("%dx%d %v with %d workers: canFitWorkers() = %v, want %v", w, h, region.counts, workers, got, want)
+						}
+					}
+					if want {
+						fits++
+					}
+				}
+			}
+		}
+	}
+	if fits == 0 {
+		t.Error("no test region fits")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	allMasks, regions, cells, err := parseInput(strings.Split(example, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	region := regions[1]
+	shapes, totalCells := regionShapes(allMasks, region, cells, nil)
+	s := &Solver{grid: newGrid(region.width, region.height), allMasks: allMasks, shapes: shapes}
+	tasks, solved := s.split(region.width*region.height-totalCells, 32)
+	if solved || len(tasks) < 32 {
+		t.Fatalf("split() = %d tasks, solved %v; want at least 32 open tasks", len(tasks), solved)
+	}
+
+	// Some subtree must hold a packing
+	found := 0
+	for _, task := range tasks {
+		copy(s.grid.rows, task.rows)
+		s.shapes = task.shapes
+		if s.solve(task.idx, task.skipsLeft) {
+			found++
+		}
+	}
+	if found == 0 {
+		t.Error("no subtree holds a packing")
+	}
+
+	// A set stop flag abandons the search
+	var stop atomic.Bool
+	stop.Store(true)
+	s.stop = &stop
+	copy(s.grid.rows, tasks[0].rows)
+	s.shapes = tasks[0].shapes
+	if s.solve(tasks[0].idx, tasks[0].skipsLeft) {
+		t.Error("solve() with stop set = true")
+	}
+}
+
+func TestPruning(t *testing.T) {
+	allMasks, _, cells, err := parseInput(strings.Split(mixed, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for w := 3; w <= 5; w++ {
+		for h := 3; h <= w; h++ {
+			for l := range 4 {
+				for p := range 4 {
+					region := Region{width: w, height: h, counts: []int{l, p}}
+					want := canFitExact(allMasks, region)
+					for _, prune := range []pruneFlags{0, pruneDeadSpace, pruneSymmetry, pruneAll} {
+						if got, _ := canFitStats(allMasks, region, cells, nil, &Grid{}, 1, prune); got != want {
+							t.Errorf("%dx%d %v with pruning %b: fit = %v, want %v", w, h, region.counts, prune, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Dead space cuts the search on the second example region
+	allMasks, regions, cells, err := parseInput(strings.Split(example, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fit, plain := canFitStats(allMasks, regions[1], cells, nil, &Grid{}, 1, 0)
+	pruned, stats := canFitStats(allMasks, regions[1], cells, nil, &Grid{}, 1, pruneAll)
+	if !fit || !pruned {
+		t.Fatalf("fit = %v unpruned, %v pruned; want true", fit, pruned)
+	}
+	if stats.DeadCells == 0 || stats.Nodes >= plain.Nodes {
+		t.Errorf("pruned search %+v, unpruned %+v; want fewer nodes", stats, plain)
+	}
+
+	// Symmetry is broken even when every shape has several copies
+	region := Region{width: 8, height: 6, counts: []int{2, 0, 0, 0, 2, 2}}
+	fit, plain = canFitStats(allMasks, region, cells, nil, &Grid{}, 1, pruneDeadSpace)
+	pruned, stats = canFitStats(allMasks, region, cells, nil, &Grid{}, 1, pruneAll)
+	if fit || pruned {
+		t.Fatalf("fit = %v without symmetry, %v with; want false", fit, pruned)
+	}
+	if stats.SymmetryPrunes == 0 || stats.Nodes >= plain.Nodes {
+		t.Errorf("with symmetry %+v, without %+v; want fewer nodes", stats, plain)
+	}
+}
+
+func TestStatsTool(t *testing.T) {
+	lines := strings.Split(example, "\n")
+	var out bytes.Buffer
+	if err := statsTool(lines, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"region 2 12x5: fit true in ",
+		"region 3 12x5: fit false in ",
+		"1 of 3 regions settled without search",
+	}
+	for _, w := range want {
+		if !strings.Contains(out.String(), w) {
+			t.Errorf("stats output %q lacks %q", out.String(), w)
+		}
+	}
+	if strings.Contains(out.String(), "region 1 ") {
+		t.Errorf("stats output %q reports region 1, which needs no search", out.String())
+	}
+
+	out.Reset()
+	if err := statsTool(lines, []string{"-prune", "dead", "1", "2"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "region 1 4x4: fit true without search\nregion 2 12x5: fit true in ") ||
+		!strings.Contains(got, " 0 symmetry prunes") {
+		t.Errorf("stats for regions 1 and 2 without symmetry = %q", got)
+	}
+
+	for _, args := range [][]string{{"4"}, {"x"}, {"-prune", "some"}} {
+		if err := statsTool(lines, args, io.Discard); err == nil {
+			t.Errorf("stats %v succeeded, want error", args)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	allMasks, _, _, err := parseInput(strings.Split(mixed, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type cellSet = [25]bool
+	for _, size := range [][2]int{{4, 3}, {5, 3}, {4, 4}, {5, 5}} {
+		w, h := size[0], size[1]
+		var all []placement
+		canon := make(map[cellSet]bool)
+		for mi := range allMasks[1] {
+			m := &allMasks[1][mi]
+			for r := 0; r+m.maxRow < h; r++ {
+				for c := 0; c+m.maxCol < w; c++ {
+					p := placement{shape: 1, mask: m, row: r, col: c}
+					all = append(all, p)
+					if canonical(w, h, m, r, c) {
+						var cs cellSet
+						p.cells(func(r, c int) { cs[r*w+c] = true })
+						canon[cs] = true
+					}
+				}
+			}
+		}
+		// Every placement must map onto a canonical one
+		for _, p := range all {
+			ok := false
+			for _, sym := range rectSymmetries(w, h) {
+				var cs cellSet
+				p.cells(func(r, c int) {
+					r, c = sym(r, c)
+					cs[r*w+c] = true
+				})
+				ok = ok || canon[cs]
+			}
+			if !ok {
+				t.Errorf("%dx%d: placement %v has no canonical image", w, h, p)
+			}
+		}
+		if len(canon) == len(all) {
+			t.Errorf("%dx%d: every placement is canonical", w, h)
+		}
+	}
+}
+
+func TestParseInputErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		line, col int
+	}{
+		{"no regions", "0:\n###\n", 0, 0},
+		{"bad width", "0:\n###\n\nax4: 1", 4, 1},
+		{"bad height", "0:\n###\n\n4x?: 1", 4, 3},
+		{"bad count", "0:\n###\n\n4x4: 1 z", 4, 8},
+		{"too many counts", "0:\n###\n\n4x4: 1 2", 4, 0},
+		{"bad shape cell", "0:\n#o#\n\n4x4: 1", 2, 2},
+		{"shape out of order", "1:\n###\n\n4x4: 1", 1, 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, err := parseInput(strings.Split(tc.input, "\n"))
+			var pe *aoc.ParseError
+			if !errors.As(err, &pe) || pe.Line != tc.line || pe.Column != tc.col {
+				t.Errorf("parseInput error = %v, want ParseError at %d:%d", err, tc.line, tc.col)
+			}
+		})
+	}
+}
+
+func loadInput() []string {
+	data, err := os.ReadFile("../../inputs/day12.txt")
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func BenchmarkPart1(b *testing.B) {
+	lines := loadInput()
+	if lines == nil {
+		b.Skip("input file not found")
+	}
+	b.ResetTimer()
+	for range b.N {
+		part1(lines)
+	}
+}
+
+func BenchmarkPart1Sequential(b *testing.B) {
+	lines := loadInput()
+	if lines == nil {
+		b.Skip("input file not found")
+	}
+	b.ResetTimer()
+	for range b.N {
+		part1Sequential(lines)
+	}
+}
+
+func BenchmarkPart1Arithmetic(b *testing.B) {
+	lines := loadInput()
+	if lines == nil {
+		b.Skip("input file not found")
+	}
+	b.ResetTimer()
+	for range b.N {
+		part1Arithmetic(lines)
+	}
+}
+
+// BenchmarkFit compares the complete searches on the second example region
+func BenchmarkFit(b *testing.B) {
+	allMasks, regions, cells, err := parseInput(strings.Split(example, "\n"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	region := regions[1]
+	b.Run("backtracking", func(b *testing.B) {
+		for range b.N {
+			canFit(allMasks, region, cells, nil, &Grid{})
+		}
+	})
+	b.Run("exact-cover", func(b *testing.B) {
+		for range b.N {
+			canFitExact(allMasks, region)
+		}
+	})
+	b.Run("sat", func(b *testing.B) {
+		for range b.N {
+			canFitSAT(allMasks, region)
+		}
+	})
+}
+
+func TestArithmeticVsActual(t *testing.T) {
+	lines := loadInput()
+	if lines == nil {
+		t.Skip("input file not found")
+	}
+	actual, err := part1(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	arithmetic, err := part1Arithmetic(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Actual: %d, Arithmetic: %d, Diff: %d", actual, arithmetic, arithmetic-actual)
+
+	answers, err := aoc.LoadAnswers("../../inputs/answers.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, ok := answers.Get(12, 1); ok && strconv.Itoa(actual) != want {
+		t.Errorf("part1() = %d, accepted answer is %s", actual, want)
+	}
+}
@@ -0,0 +1,112 @@
+package day12
+
+import (
+	"cmp"
+	"slices"
+)
+
+// packResult is the best partial packing found for a region
+type packResult struct {
+	placed  []placement
+	value   int   // presents placed, or cells covered when maximising area
+	omitted []int // presents of each shape left out
+}
+
+// maxPacking places as many of region's presents as possible, counting
+// presents or, with byArea, the cells they cover. It searches the moves of
+// Solver.branch with no limit on empty cells, and prunes a node when what is
+// placed plus an upper bound on what could still fit in the free cells is
+// no better than the best packing so far.
+func maxPacking(allMasks [][]ShapeMask, region Region, cells []int, byArea bool) packResult {
+	remaining := make([]int, len(allMasks))
+	var shapes []ShapeEntry
+	for shapeIdx, n := range region.counts {
+		if len(allMasks[shapeIdx]) == 0 || cells[shapeIdx] == 0 {
+			continue
+		}
+		remaining[shapeIdx] = n
+		for range n {
+			shapes = append(shapes, ShapeEntry{shapeIdx: shapeIdx, cellCount: cells[shapeIdx]})
+		}
+	}
+	weight := func(shapeIdx int) int {
+		if byArea {
+			return cells[shapeIdx]
+		}
+		return 1
+	}
+	total := 0
+	for _, se := range shapes {
+		total += weight(se.shapeIdx)
+	}
+
+	// Smallest shapes first: taking them greedily maximises the count that
+	// fits in a number of cells
+	bySize := make([]int, len(allMasks))
+	for i := range bySize {
+		bySize[i] = i
+	}
+	slices.SortFunc(bySize, func(a, b int) int { return cmp.Compare(cells[a], cells[b]) })
+	// bound caps how many more presents fit in free cells, smallest first;
+	// with byArea it caps the cells covered by that many of the largest
+	bound := func(free int) int {
+		fit, room := 0, free
+		for _, shapeIdx := range bySize {
+			k := min(remaining[shapeIdx], room/max(cells[shapeIdx], 1))
+			fit += k
+			room -= k * cells[shapeIdx]
+			if k < remaining[shapeIdx] {
+				break
+			}
+		}
+		if !byArea {
+			return fit
+		}
+		area := 0
+		for _, shapeIdx := range slices.Backward(bySize) {
+			k := min(remaining[shapeIdx], fit)
+			area += k * cells[shapeIdx]
+			fit -= k
+		}
+		return min(free, area)
+	}
+
+	area := region.width * region.height
+	s := newSolver(newGrid(region.width, region.height), allMasks, shapes, 0)
+	best := packResult{omitted: slices.Clone(remaining)}
+	var placed []placement
+	value, covered := 0, 0
+
+	// search returns false once every present is placed, as nothing can beat
+	// that. The skip budget starts at the whole area and only skips spend it,
+	// so skipsLeft-covered is the number of free cells.
+	var search func(idx, skipsLeft int) bool
+	search = func(idx, skipsLeft int) bool {
+		if value > best.value {
+			best = packResult{placed: slices.Clone(placed), value: value, omitted: slices.Clone(remaining)}
+		}
+		if value == total {
+			return false
+		}
+		if value+bound(skipsLeft-covered) <= best.value {
+			return true
+		}
+		return s.branch(idx, skipsLeft, func(p placement, next, skipsLeft int) bool {
+			if p.mask == nil {
+				return search(next, skipsLeft)
+			}
+			remaining[p.shape]--
+			value += weight(p.shape)
+			covered += cells[p.shape]
+			placed = append(placed, p)
+			more := search(next, skipsLeft)
+			placed = placed[:len(placed)-1]
+			covered -= cells[p.shape]
+			value -= weight(p.shape)
+			remaining[p.shape]++
+			return more
+		})
+	}
+	search(0, area)
+	return best
+}
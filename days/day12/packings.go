@@ -0,0 +1,119 @@
+package day12
+
+import (
+	"iter"
+	"slices"
+)
+
+// packings yields every distinct way to place all of s.shapes in s.grid,
+// leaving at most skips cells empty. Every move branch makes is a different
+// use of the first empty cell, so each packing is found exactly once. The
+// slice is reused between packings.
+func (s *Solver) packings(skips int) iter.Seq[[]placement] {
+	return func(yield func([]placement) bool) {
+		var placed []placement
+		var search func(idx, skipsLeft int) bool
+		search = func(idx, skipsLeft int) bool {
+			if idx == len(s.shapes) {
+				return yield(placed)
+			}
+			return s.branch(idx, skipsLeft, func(p placement, next, skipsLeft int) bool {
+				if p.mask == nil {
+					return search(next, skipsLeft)
+				}
+				placed = append(placed, p)
+				more := search(next, skipsLeft)
+				placed = placed[:len(placed)-1]
+				return more
+			})
+		}
+		search(0, skips)
+	}
+}
+
+// rectSymmetries returns the maps of a width x height rectangle onto itself:
+// the identity, the half turn and both mirrors, plus for a square the
+// quarter turns and the diagonal mirrors
+func rectSymmetries(width, height int) []func(r, c int) (int, int) {
+	w, h := width-1, height-1
+	syms := []func(r, c int) (int, int){
+		func(r, c int) (int, int) { return r, c },
+		func(r, c int) (int, int) { return h - r, w - c },
+		func(r, c int) (int, int) { return r, w - c },
+		func(r, c int) (int, int) { return h - r, c },
+	}
+	if width == height {
+		syms = append(syms,
+			func(r, c int) (int, int) { return c, w - r },
+			func(r, c int) (int, int) { return w - c, r },
+			func(r, c int) (int, int) { return c, r },
+			func(r, c int) (int, int) { return w - c, h - r },
+		)
+	}
+	return syms
+}
+
+// packingKey labels every cell with its present's shape and the present's
+// first cell after applying sym, so two packings have equal keys exactly
+// when sym maps one onto the other
+func packingKey(ps []placement, width, height int, sym func(r, c int) (int, int), key []int) []int {
+	area := width * height
+	key = append(key[:0], make([]int, area)...)
+	for _, p := range ps {
+		first := area
+		p.cells(func(r, c int) {
+			r, c = sym(r, c)
+			first = min(first, r*width+c)
+		})
+		p.cells(func(r, c int) {
+			r, c = sym(r, c)
+			key[r*width+c] = (p.shape+1)*area + first
+		})
+	}
+	return key
+}
+
+// enumeratePackings yields every distinct packing of region as a list of
+// placements. With modSymmetry, packings that a symmetry of the rectangle
+// maps onto each other count as one, and only the one with the smallest
+// key is yielded. The slice is reused between packings.
+func enumeratePackings(allMasks [][]ShapeMask, region Region, cells []int, modSymmetry bool) iter.Seq[[]placement] {
+	var shapes []ShapeEntry
+	totalCells := 0
+	for shapeIdx, n := range region.counts {
+		if len(allMasks[shapeIdx]) == 0 || cells[shapeIdx] == 0 {
+			continue
+		}
+		for range n {
+			shapes = append(shapes, ShapeEntry{shapeIdx: shapeIdx, cellCount: cells[shapeIdx]})
+		}
+		totalCells += n * cells[shapeIdx]
+	}
+	area := region.width * region.height
+	if totalCells > area {
+		return func(func([]placement) bool) {}
+	}
+	solver := newSolver(newGrid(region.width, region.height), allMasks, shapes, pruneDeadSpace)
+	packings := solver.packings(area - totalCells)
+	if !modSymmetry {
+		return packings
+	}
+
+	syms := rectSymmetries(region.width, region.height)
+	return func(yield func([]placement) bool) {
+		var key, image []int
+	next:
+		for ps := range packings {
+			key = packingKey(ps, region.width, region.height, syms[0], key)
+			for _, sym := range syms[1:] {
+				image = packingKey(ps, region.width, region.height, sym, image)
+				if slices.Compare(image, key) < 0 {
+					continue next
+				}
+			}
+			if !yield(ps) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package day12
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// tasksPerWorker is how many subtrees solveParallel aims to give each
+// worker, so that one hard subtree does not leave the others idle
+const tasksPerWorker = 8
+
+// searchTask is a subtree of Solver.solve: the grid and shape order after
+// some moves from the root, and the arguments to carry on with
+type searchTask struct {
+	rows               []uint64
+	shapes             []ShapeEntry
+	idx                int
+	skipsLeft          int
+	symLeft, symPlaced int
+}
+
+// split expands the search breadth first until there are at least n open
+// subtrees. It reports solved if it reaches a complete packing on the way.
+func (s *Solver) split(skipsLeft, n int) (tasks []searchTask, solved bool) {
+	tasks = []searchTask{{
+		rows:      slices.Clone(s.grid.rows),
+		shapes:    slices.Clone(s.shapes),
+		skipsLeft: skipsLeft,
+		symLeft:   s.symLeft,
+		symPlaced: s.symPlaced,
+	}}
+	for len(tasks) > 0 && len(tasks) < n {
+		t := tasks[0]
+		tasks = tasks[1:]
+		copy(s.grid.rows, t.rows)
+		s.shapes = t.shapes
+		s.symLeft, s.symPlaced = t.symLeft, t.symPlaced
+		s.branch(t.idx, t.skipsLeft, func(_ placement, idx, skipsLeft int) bool {
+			if idx == len(s.shapes) {
+				solved = true
+			}
+			tasks = append(tasks, searchTask{
+				rows:      slices.Clone(s.grid.rows),
+				shapes:    slices.Clone(s.shapes),
+				idx:       idx,
+				skipsLeft: skipsLeft,
+				symLeft:   s.symLeft,
+				symPlaced: s.symPlaced,
+			})
+			return !solved
+		})
+		if solved {
+			return nil, true
+		}
+	}
+	return tasks, false
+}
+
+// solveParallel is solve(0, skipsLeft) with the top of the search tree
+// split into subtrees for a pool of workers. The first worker to find a
+// packing stops the rest.
+func (s *Solver) solveParallel(skipsLeft, workers int) bool {
+	tasks, solved := s.split(skipsLeft, workers*tasksPerWorker)
+	if solved {
+		return true
+	}
+
+	var found atomic.Bool
+	ch := make(chan searchTask)
+	var wg sync.WaitGroup
+	stats := make([]SolverStats, workers)
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g := *s.grid
+			g.rows = make([]uint64, len(s.grid.rows))
+			w := &Solver{
+				grid:          &g,
+				allMasks:      s.allMasks,
+				stop:          &found,
+				prune:         s.prune,
+				breakSymmetry: s.breakSymmetry,
+				symShape:      s.symShape,
+				symLastRow:    s.symLastRow,
+			}
+			defer func() { stats[i] = w.stats }()
+			for t := range ch {
+				if found.Load() {
+					continue
+				}
+				copy(g.rows, t.rows)
+				w.shapes = t.shapes
+				w.symLeft, w.symPlaced = t.symLeft, t.symPlaced
+				if w.solve(t.idx, t.skipsLeft) {
+					found.Store(true)
+				}
+			}
+		}()
+	}
+	for _, t := range tasks {
+		if found.Load() {
+			break
+		}
+		ch <- t
+	}
+	close(ch)
+	wg.Wait()
+	for _, st := range stats {
+		s.stats.add(st)
+	}
+	return found.Load()
+}
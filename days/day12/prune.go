@@ -0,0 +1,134 @@
+package day12
+
+import "fmt"
+
+// pruneFlags selects the optional pruning in Solver.solve
+type pruneFlags int
+
+const (
+	// pruneDeadSpace fills enclosed pockets too small for any remaining
+	// present as soon as they appear, charging them to the skip budget
+	pruneDeadSpace pruneFlags = 1 << iota
+	// pruneSymmetry keeps at least one present of the shape with the fewest
+	// copies in one corner of the rectangle, as the rectangle's symmetries
+	// map every packing to one that does
+	pruneSymmetry
+
+	pruneAll = pruneDeadSpace | pruneSymmetry
+)
+
+// SolverStats counts the work done by Solver.solve on one region
+type SolverStats struct {
+	Nodes          int // search nodes that branched
+	Placements     int // presents placed
+	Skips          int // cells left empty by choice
+	DeadCells      int // cells filled because no remaining present fits them
+	DeadPrunes     int // placements whose dead space overran the skip budget
+	SymmetryPrunes int // placements and nodes cut to keep a symShape present canonical
+}
+
+func (a SolverStats) String() string {
+	return fmt.Sprintf("%d nodes, %d placements, %d skips, %d dead cells, %d dead prunes, %d symmetry prunes",
+		a.Nodes, a.Placements, a.Skips, a.DeadCells, a.DeadPrunes, a.SymmetryPrunes)
+}
+
+func (a *SolverStats) add(b SolverStats) {
+	a.Nodes += b.Nodes
+	a.Placements += b.Placements
+	a.Skips += b.Skips
+	a.DeadCells += b.DeadCells
+	a.DeadPrunes += b.DeadPrunes
+	a.SymmetryPrunes += b.SymmetryPrunes
+}
+
+// symmetryAllows reports whether the present at s.shapes[idx] may go at
+// (startR, startC), and whether it would be canonical there. Any present
+// of s.symShape may be placed anywhere except the last one, which must be
+// canonical if none placed before it is.
+func (s *Solver) symmetryAllows(idx int, m *ShapeMask, startR, startC int) (ok, canon bool) {
+	if !s.breakSymmetry || s.shapes[idx].shapeIdx != s.symShape {
+		return true, false
+	}
+	canon = canonical(s.grid.width, s.grid.height, m, startR, startC)
+	return canon || s.symPlaced > 0 || s.symLeft > 1, canon
+}
+
+// symmetryDead reports whether no packing below the node whose first empty
+// cell is on row r can be canonical: none of s.symShape is placed
+// canonically yet, and every later present starts on row r or below.
+func (s *Solver) symmetryDead(r int) bool {
+	return s.breakSymmetry && s.symPlaced == 0 && r > s.symLastRow
+}
+
+func (g *Grid) empty(r, c int) bool {
+	return g.rows[r*g.words+c/64]>>(c%64)&1 == 0
+}
+
+// fillPockets looks at the empty cells next to a present just placed at
+// (startR, startC) and fills every enclosed pocket smaller than the
+// smallest of s.shapes[next:], since no present can go there. It returns
+// the number of cells filled; unfill undoes it.
+func (s *Solver) fillPockets(m *ShapeMask, startR, startC, next int) int {
+	if s.prune&pruneDeadSpace == 0 || next == len(s.shapes) {
+		return 0
+	}
+	smallest := s.shapes[next].cellCount
+	for _, se := range s.shapes[next+1:] {
+		smallest = min(smallest, se.cellCount)
+	}
+	if smallest <= 1 {
+		return 0
+	}
+
+	filled := 0
+	for _, shift := range m.bitShifts {
+		r, c := startR+shift>>8, startC+shift&0xFF
+		for _, d := range [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			nr, nc := r+d[0], c+d[1]
+			if nr >= 0 && nr < s.grid.height && nc >= 0 && nc < s.grid.width && s.grid.empty(nr, nc) {
+				filled += s.fillPocket(nr, nc, smallest)
+			}
+		}
+	}
+	s.stats.DeadCells += filled
+	return filled
+}
+
+// fillPocket floods the empty region from (r, c), marking cells filled as
+// it goes. If the region turns out to have fewer than limit cells they stay
+// filled, pushed on s.dead, and their number is returned; otherwise the
+// flood is undone as soon as it reaches limit cells.
+func (s *Solver) fillPocket(r, c, limit int) int {
+	g := s.grid
+	pocket := s.pocket[:0]
+	g.setCell(r, c)
+	pocket = append(pocket, r*g.width+c)
+	for i := 0; i < len(pocket) && len(pocket) < limit; i++ {
+		r, c := pocket[i]/g.width, pocket[i]%g.width
+		for _, d := range [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			nr, nc := r+d[0], c+d[1]
+			if nr >= 0 && nr < g.height && nc >= 0 && nc < g.width && g.empty(nr, nc) {
+				g.setCell(nr, nc)
+				pocket = append(pocket, nr*g.width+nc)
+			}
+		}
+	}
+	s.pocket = pocket
+
+	if len(pocket) >= limit {
+		for _, cell := range pocket {
+			g.clearCell(cell/g.width, cell%g.width)
+		}
+		return 0
+	}
+	s.dead = append(s.dead, pocket...)
+	return len(pocket)
+}
+
+// unfill empties the dead cells filled since s.dead had length mark
+func (s *Solver) unfill(mark int) {
+	for _, cell := range s.dead[mark:] {
+		s.grid.clearCell(cell/s.grid.width, cell%s.grid.width)
+	}
+	s.dead = s.dead[:mark]
+}
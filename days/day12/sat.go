@@ -0,0 +1,93 @@
+package day12
+
+import (
+	"fmt"
+	"io"
+
+	"aoc2025/internal/sat"
+)
+
+// regionCNF encodes region as a SAT problem. Variable i+1 says placements[i]
+// is used; sequential counters place each shape exactly as often as the
+// region asks, and every cell is covered at most once. One more clause
+// asks for a present of the shape with the fewest copies to be canonical,
+// so CDCL does not refute each mirror image of a dead end separately.
+func regionCNF(allMasks [][]ShapeMask, region Region) (*sat.CNF, []placement) {
+	f := &sat.CNF{Comments: []string{
+		fmt.Sprintf("day12 region %dx%d, counts %v", region.width, region.height, region.counts),
+	}}
+	var placements []placement
+	byShape := make([][]int, len(region.counts))
+	covering := make([][]int, region.width*region.height)
+	symShape := -1
+	for shapeIdx, n := range region.counts {
+		if n > 0 && len(allMasks[shapeIdx]) > 0 && (symShape < 0 || n < region.counts[symShape]) {
+			symShape = shapeIdx
+		}
+	}
+	var symVars []int
+	for shapeIdx, n := range region.counts {
+		if n == 0 || len(allMasks[shapeIdx]) == 0 {
+			continue
+		}
+		for mi := range allMasks[shapeIdx] {
+			m := &allMasks[shapeIdx][mi]
+			for r := 0; r+m.maxRow < region.height; r++ {
+				for c := 0; c+m.maxCol < region.width; c++ {
+					p := placement{shape: shapeIdx, mask: m, row: r, col: c}
+					v := f.NewVar()
+					placements = append(placements, p)
+					byShape[shapeIdx] = append(byShape[shapeIdx], v)
+					if shapeIdx == symShape && canonical(region.width, region.height, m, r, c) {
+						symVars = append(symVars, v)
+					}
+					p.cells(func(r, c int) {
+						cell := r*region.width + c
+						covering[cell] = append(covering[cell], v)
+					})
+				}
+			}
+		}
+	}
+
+	// Counters add auxiliary variables, so only after every placement has one
+	for shapeIdx, n := range region.counts {
+		if n > 0 && len(allMasks[shapeIdx]) > 0 {
+			f.ExactlyK(byShape[shapeIdx], n)
+		}
+	}
+	for _, vars := range covering {
+		f.AtMostOne(vars)
+	}
+	if symShape >= 0 {
+		f.Add(symVars...)
+	}
+	f.Comments = append(f.Comments, fmt.Sprintf("variables 1-%d are placements, the rest counter auxiliaries", len(placements)))
+	return f, placements
+}
+
+// canonical reports whether placing m at (startR, startC) in a width x
+// height rectangle is canonical: the centre of its bounding box must lie in
+// the top-left quarter and, for a square, on or above the diagonal. Mirrors
+// and, for a square, transposition move any placement there, and
+// allOrientations makes the moved one available too. So in any packing one
+// chosen present can be made canonical, whichever it is.
+func canonical(width, height int, m *ShapeMask, startR, startC int) bool {
+	// Centres doubled to stay integral
+	cr, cc := 2*startR+m.maxRow, 2*startC+m.maxCol
+	return cr <= height-1 && cc <= width-1 && (width != height || cr <= cc)
+}
+
+// canFitSAT decides a region with the CDCL solver. It finds packings
+// quickly, but refuting a region is slow even with the symmetry clause:
+// the third example region takes about 40 seconds.
+func canFitSAT(allMasks [][]ShapeMask, region Region) bool {
+	f, _ := regionCNF(allMasks, region)
+	return sat.New(f).Solve()
+}
+
+// writeRegionDIMACS writes region's encoding for an external SAT solver
+func writeRegionDIMACS(w io.Writer, allMasks [][]ShapeMask, region Region) error {
+	f, _ := regionCNF(allMasks, region)
+	return f.WriteDIMACS(w)
+}
@@ -0,0 +1,274 @@
+package day12
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"aoc2025/internal/aoc"
+)
+
+// regionArgs resolves region numbers, counted from 1 in input order, to
+// indexes into regions; no arguments selects every region
+func regionArgs(regions []Region, args []string) ([]int, error) {
+	if len(args) == 0 {
+		all := make([]int, len(regions))
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	}
+	var idx []int
+	for _, a := range args {
+		n, err := strconv.Atoi(a)
+		if err != nil || n < 1 || n > len(regions) {
+			return nil, fmt.Errorf("invalid region %q: want 1 to %d", a, len(regions))
+		}
+		idx = append(idx, n-1)
+	}
+	return idx, nil
+}
+
+// parsePrune converts a -prune value to pruneFlags
+func parsePrune(s string) (pruneFlags, error) {
+	switch s {
+	case "none":
+		return 0, nil
+	case "dead":
+		return pruneDeadSpace, nil
+	case "symmetry":
+		return pruneSymmetry, nil
+	case "all":
+		return pruneAll, nil
+	}
+	return 0, fmt.Errorf("invalid pruning %q: want none, dead, symmetry or all", s)
+}
+
+// statsTool reports the work backtracking does on each region that the
+// quick checks leave open, or on the regions named
+func statsTool(lines, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	workers := fs.Int("workers", 1, "share each search among `n` workers")
+	pruning := fs.String("prune", "all", "pruning to use: none, dead, symmetry or all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	prune, err := parsePrune(*pruning)
+	if err != nil {
+		return err
+	}
+	allMasks, regions, cells, err := parseInput(lines)
+	if err != nil {
+		return err
+	}
+	selected, err := regionArgs(regions, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	var shapes []ShapeEntry
+	g := &Grid{}
+	settled := 0
+	for _, i := range selected {
+		region := regions[i]
+		var totalCells int
+		shapes, totalCells = regionShapes(allMasks, region, cells, shapes)
+		if fit, ok := quickFit(allMasks, region, shapes, totalCells, g); ok {
+			if fs.NArg() > 0 {
+				fmt.Fprintf(w, "region %d %dx%d: fit %v without search\n", i+1, region.width, region.height, fit)
+			}
+			settled++
+			continue
+		}
+		start := time.Now()
+		fit, stats := searchFit(allMasks, region, shapes, totalCells, g, max(*workers, 1), prune)
+		fmt.Fprintf(w, "region %d %dx%d: fit %v in %v, %v\n", i+1, region.width, region.height, fit, time.Since(start), stats)
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintf(w, "%d of %d regions settled without search\n", settled, len(selected))
+	}
+	return nil
+}
+
+// drawPacking writes the region with each present's cells marked by its
+// own letter and empty cells as '.'
+func drawPacking(w io.Writer, width, height int, ps []placement) {
+	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	cells := make([][]byte, height)
+	for r := range cells {
+		cells[r] = bytes.Repeat([]byte{'.'}, width)
+	}
+	for i, p := range ps {
+		p.cells(func(r, c int) { cells[r][c] = letters[i%len(letters)] })
+	}
+	for _, row := range cells {
+		fmt.Fprintf(w, "%s\n", row)
+	}
+}
+
+// packingsTool counts the distinct packings of one region and can draw them
+func packingsTool(lines, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("packings", flag.ContinueOnError)
+	modSymmetry := fs.Bool("sym", false, "count packings related by a symmetry of the region once")
+	draw := fs.Bool("draw", false, "draw each packing as it is found")
+	limit := fs.Int("n", 0, "stop after `n` packings; 0 finds them all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	allMasks, regions, cells, err := parseInput(lines)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("want one region, got %d", fs.NArg())
+	}
+	selected, err := regionArgs(regions, fs.Args())
+	if err != nil {
+		return err
+	}
+	region := regions[selected[0]]
+
+	n := 0
+	for ps := range enumeratePackings(allMasks, region, cells, *modSymmetry) {
+		n++
+		if *draw {
+			fmt.Fprintf(w, "packing %d:\n", n)
+			drawPacking(w, region.width, region.height, ps)
+			fmt.Fprintln(w)
+		}
+		if n == *limit {
+			break
+		}
+	}
+	fmt.Fprintf(w, "region %d %dx%d: %d packings\n", selected[0]+1, region.width, region.height, n)
+	return nil
+}
+
+// maxpackTool packs as many presents as possible into each region named
+// and reports which ones had to be left out
+func maxpackTool(lines, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("maxpack", flag.ContinueOnError)
+	byArea := fs.Bool("area", false, "maximise the cells covered rather than the presents placed")
+	draw := fs.Bool("draw", false, "draw the best packing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	allMasks, regions, cells, err := parseInput(lines)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("want at least one region")
+	}
+	selected, err := regionArgs(regions, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	unit := "presents"
+	if *byArea {
+		unit = "cells"
+	}
+	for _, i := range selected {
+		region := regions[i]
+		best := maxPacking(allMasks, region, cells, *byArea)
+		total := best.value
+		var omitted []string
+		for shapeIdx, n := range best.omitted {
+			if n == 0 {
+				continue
+			}
+			omitted = append(omitted, fmt.Sprintf("%d of shape %d", n, shapeIdx))
+			if *byArea {
+				total += n * cells[shapeIdx]
+			} else {
+				total += n
+			}
+		}
+		if len(omitted) == 0 {
+			omitted = append(omitted, "nothing")
+		}
+		fmt.Fprintf(w, "region %d %dx%d: %d of %d %s, omitting %s\n",
+			i+1, region.width, region.height, best.value, total, unit, strings.Join(omitted, ", "))
+		if *draw {
+			drawPacking(w, region.width, region.height, best.placed)
+		}
+	}
+	return nil
+}
+
+// satTool decides each region that the quick checks leave open, or the
+// regions named, with canFitSAT, and counts the regions that fit
+func satTool(lines, args []string, w io.Writer) error {
+	allMasks, regions, cells, err := parseInput(lines)
+	if err != nil {
+		return err
+	}
+	selected, err := regionArgs(regions, args)
+	if err != nil {
+		return err
+	}
+
+	var shapes []ShapeEntry
+	g := &Grid{}
+	count := 0
+	for _, i := range selected {
+		region := regions[i]
+		var totalCells int
+		shapes, totalCells = regionShapes(allMasks, region, cells, shapes)
+		fit, ok := quickFit(allMasks, region, shapes, totalCells, g)
+		if ok {
+			if len(args) > 0 {
+				fmt.Fprintf(w, "region %d %dx%d: fit %v without search\n", i+1, region.width, region.height, fit)
+			}
+		} else {
+			start := time.Now()
+			fit = canFitSAT(allMasks, region)
+			fmt.Fprintf(w, "region %d %dx%d: fit %v in %v\n", i+1, region.width, region.height, fit, time.Since(start))
+		}
+		if fit {
+			count++
+		}
+	}
+	fmt.Fprintf(w, "%d of %d regions fit\n", count, len(selected))
+	return nil
+}
+
+// dimacsTool writes the SAT encoding of a region in DIMACS form, to
+// stdout or a file, for an external solver
+func dimacsTool(lines, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("dimacs", flag.ContinueOnError)
+	out := fs.String("o", "", "write the encoding to `file` instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	allMasks, regions, _, err := parseInput(lines)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("want one region, got %d", fs.NArg())
+	}
+	selected, err := regionArgs(regions, fs.Args())
+	if err != nil {
+		return err
+	}
+	region := regions[selected[0]]
+
+	if *out == "" {
+		return writeRegionDIMACS(w, allMasks, region)
+	}
+	var buf bytes.Buffer
+	if err := writeRegionDIMACS(&buf, allMasks, region); err != nil {
+		return err
+	}
+	if err := aoc.WriteFileAtomic(*out, buf.Bytes()); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "region %d %dx%d: wrote %s\n", selected[0]+1, region.width, region.height, *out)
+	return nil
+}
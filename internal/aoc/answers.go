@@ -0,0 +1,103 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Answer holds the accepted answers for one day
+type Answer struct {
+	Part1 string `json:"part1,omitempty"`
+	Part2 string `json:"part2,omitempty"`
+}
+
+// Answers maps day numbers to accepted answers, as stored in inputs/answers.json
+type Answers map[int]Answer
+
+// AnswersPath returns the location of the answer store under root
+func AnswersPath(root string) string {
+	return filepath.Join(root, "inputs", "answers.json")
+}
+
+// LoadAnswers reads the answer store at path; a missing file is an empty store
+func LoadAnswers(path string) (Answers, error) {
+	a := Answers{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return a, nil
+}
+
+// Save writes the store to path
+func (a Answers) Save(path string) error {
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, append(data, '\n'))
+}
+
+// Get returns the accepted answer for day and part, if known
+func (a Answers) Get(day, part int) (string, bool) {
+	ans := a[day]
+	v := ans.Part1
+	if part == 2 {
+		v = ans.Part2
+	}
+	return v, v != ""
+}
+
+// Set records the accepted answer for day and part
+func (a Answers) Set(day, part int, v string) {
+	ans := a[day]
+	if part == 2 {
+		ans.Part2 = v
+	} else {
+		ans.Part1 = v
+	}
+	a[day] = ans
+}
+
+// Check is the result of running one solver against a known answer
+type Check struct {
+	Label   string // "Part 1" or "Part 1 (variant)"
+	Part    int
+	Want    string
+	Got     int
+	Elapsed time.Duration
+	Err     error // the solver failed
+}
+
+// OK reports whether the solver ran and matched the known answer
+func (c Check) OK() bool {
+	return c.Err == nil && strconv.Itoa(c.Got) == c.Want
+}
+
+// Verify runs every solver of d, including variants, for which a known
+// answer exists and compares the results.
+func (d Day) Verify(lines []string, answers Answers) []Check {
+	var checks []Check
+	for p := 1; p <= 2; p++ {
+		want, ok := answers.Get(d.Number, p)
+		if !ok {
+			continue
+		}
+		for _, t := range d.tasks(p) {
+			got, elapsed, err := t.run(lines)
+			checks = append(checks, Check{Label: t.label, Part: p, Want: want, Got: got, Elapsed: elapsed, Err: err})
+		}
+	}
+	return checks
+}
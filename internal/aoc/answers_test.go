@@ -0,0 +1,78 @@
+package aoc
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAnswersSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	a, err := LoadAnswers(path)
+	if err != nil || len(a) != 0 {
+		t.Fatalf("LoadAnswers(missing) = %v, %v", a, err)
+	}
+	a.Set(3, 1, "357")
+	a.Set(3, 2, "171435596092638")
+	a.Set(12, 1, "4")
+	if err := a.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		day, part int
+		want      string
+		ok        bool
+	}{
+		{3, 1, "357", true},
+		{3, 2, "171435596092638", true},
+		{12, 1, "4", true},
+		{12, 2, "", false},
+		{5, 1, "", false},
+	} {
+		if got, ok := b.Get(tc.day, tc.part); got != tc.want || ok != tc.ok {
+			t.Errorf("Get(%d, %d) = %q, %v; want %q, %v", tc.day, tc.part, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	d := Day{
+		Number: 99,
+		Part1:  func(lines []string) (int, error) { return len(lines), nil },
+		Part2:  func(lines []string) (int, error) { panic("boom") },
+		Variants: []Variant{
+			{Name: "off by one", Part: 1, Solve: func(lines []string) (int, error) { return len(lines) + 1, nil }},
+		},
+	}
+	answers := Answers{}
+	answers.Set(99, 1, "3")
+	answers.Set(99, 2, "0")
+
+	checks := d.Verify([]string{"a", "b", "c"}, answers)
+	want := []struct {
+		label string
+		ok    bool
+		err   bool
+	}{
+		{"Part 1", true, false},
+		{"Part 1 (off by one)", false, false},
+		{"Part 2", false, true},
+	}
+	if len(checks) != len(want) {
+		t.Fatalf("got %d checks, want %d", len(checks), len(want))
+	}
+	for i, w := range want {
+		c := checks[i]
+		if c.Label != w.label || c.OK() != w.ok || (c.Err != nil) != w.err {
+			t.Errorf("check %d = %+v, want label %q ok=%v err=%v", i, c, w.label, w.ok, w.err)
+		}
+	}
+
+	if checks := d.Verify(nil, Answers{}); len(checks) != 0 {
+		t.Errorf("Verify without answers returned %d checks", len(checks))
+	}
+}
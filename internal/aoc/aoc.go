@@ -0,0 +1,292 @@
+// Package aoc runs Advent of Code solutions: it reads the puzzle input,
+// dispatches to the parts a day provides and reports the answers.
+package aoc
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"time"
+)
+
+// Solver computes the answer for one part from the raw input lines.
+// Malformed input should be reported as a *ParseError.
+type Solver func(lines []string) (int, error)
+
+// Variant is an alternative solver for a part, reported alongside the main one
+type Variant struct {
+	Name  string
+	Part  int
+	Solve Solver
+}
+
+// Tool is a day-specific report on an input beyond the answers, run by
+// "aoc tool". It parses its own arguments and writes the report to w.
+type Tool struct {
+	Name  string
+	Usage string // arguments after the name
+	Run   func(lines, args []string, w io.Writer) error
+}
+
+// Day bundles the solvers for a single puzzle. A nil part is skipped.
+type Day struct {
+	Number   int
+	Part1    Solver
+	Part2    Solver
+	Variants []Variant
+	Tools    []Tool
+}
+
+// Options are the per-run settings, usually filled in from flags
+type Options struct {
+	Input  string // input file; stdin when empty
+	Part   string // "1", "2" or "both"
+	Timing bool   // report wall-clock time per part
+
+	// The CPU profile and trace cover only the selected solver runs, not
+	// reading the input; the allocation profile counts from process start
+	CPUProfile string // pprof CPU profile output
+	MemProfile string // pprof allocation profile output
+	Trace      string // runtime/trace output
+}
+
+// Profiling reports whether any profile output was requested
+func (o Options) Profiling() bool {
+	return o.CPUProfile != "" || o.MemProfile != "" || o.Trace != ""
+}
+
+// SetFlags registers the options as flags on fs
+func (o *Options) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Input, "input", o.Input, "read puzzle input from `file`")
+	fs.StringVar(&o.Part, "part", "both", "which part to run: 1, 2 or both")
+	fs.BoolVar(&o.Timing, "time", o.Timing, "report wall-clock time per part")
+	fs.StringVar(&o.CPUProfile, "cpuprofile", o.CPUProfile, "write a CPU profile to `file`")
+	fs.StringVar(&o.MemProfile, "memprofile", o.MemProfile, "write an allocation profile to `file`")
+	fs.StringVar(&o.Trace, "trace", o.Trace, "write an execution trace to `file`")
+}
+
+// Run executes the selected parts of d and returns an exit code:
+// 0 on success, 1 if the input could not be read or a part failed, 2 for bad options.
+func (d Day) Run(opts Options, stdin io.Reader, stdout, stderr io.Writer) int {
+	parts, err := ParsePart(opts.Part)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	in := stdin
+	if opts.Input != "" {
+		f, err := os.Open(opts.Input)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+	lines, err := ReadLines(in)
+	if err != nil {
+		fmt.Fprintln(stderr, "reading input:", err)
+		return 1
+	}
+
+	prof, err := startProfiling(opts)
+	if err != nil {
+		fmt.Fprintln(stderr, "profiling:", err)
+		return 1
+	}
+
+	status := 0
+	for _, p := range parts {
+		for _, t := range d.tasks(p) {
+			answer, elapsed, err := t.runLabelled(d.Number, lines)
+			if err != nil {
+				fmt.Fprintf(stderr, "%s: %s\n", t.label, Describe(err, lines))
+				status = 1
+				continue
+			}
+			if opts.Timing {
+				fmt.Fprintf(stdout, "%s: %d (%v)\n", t.label, answer, elapsed)
+			} else {
+				fmt.Fprintf(stdout, "%s: %d\n", t.label, answer)
+			}
+		}
+	}
+
+	if err := prof.stop(); err != nil {
+		fmt.Fprintln(stderr, "profiling:", err)
+		status = 1
+	}
+	return status
+}
+
+// ReadLines reads r to EOF and returns its lines without trailing newlines
+func ReadLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// ReadInput reads the lines of the file at path
+func ReadInput(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadLines(f)
+}
+
+// WriteFileAtomic writes data to a temporary file next to path and renames
+// it into place, so an interrupted write never leaves a truncated file behind.
+func WriteFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// ParsePart converts a -part value into the list of part numbers to run
+func ParsePart(s string) ([]int, error) {
+	switch s {
+	case "1":
+		return []int{1}, nil
+	case "2":
+		return []int{2}, nil
+	case "both", "":
+		return []int{1, 2}, nil
+	}
+	return nil, fmt.Errorf("invalid part %q: want 1, 2 or both", s)
+}
+
+// Solver returns the main solver for part p, or nil if the day has none
+func (d Day) Solver(p int) Solver {
+	switch p {
+	case 1:
+		return d.Part1
+	case 2:
+		return d.Part2
+	}
+	return nil
+}
+
+// Tool returns the tool of d with the given name
+func (d Day) Tool(name string) (Tool, bool) {
+	for _, t := range d.Tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Tool{}, false
+}
+
+// Solve runs the main solver for part p on lines, reporting a panic as an error
+func (d Day) Solve(p int, lines []string) (int, error) {
+	s := d.Solver(p)
+	if s == nil {
+		return 0, fmt.Errorf("day %d has no part %d", d.Number, p)
+	}
+	answer, _, err := task{solve: s}.run(lines)
+	return answer, err
+}
+
+// Benchmark is a named solver, as measured by aoc bench
+type Benchmark struct {
+	Name  string // "Part1" or "Part1(variant)"
+	Solve Solver
+}
+
+// Benchmarks lists every solver of d, main parts and variants, in run order
+func (d Day) Benchmarks() []Benchmark {
+	var bs []Benchmark
+	for p := 1; p <= 2; p++ {
+		if s := d.Solver(p); s != nil {
+			bs = append(bs, Benchmark{fmt.Sprintf("Part%d", p), s})
+		}
+		for _, v := range d.Variants {
+			if v.Part == p {
+				bs = append(bs, Benchmark{fmt.Sprintf("Part%d(%s)", p, v.Name), v.Solve})
+			}
+		}
+	}
+	return bs
+}
+
+// task is one labelled solver invocation
+type task struct {
+	label   string
+	part    int
+	variant string
+	solve   Solver
+}
+
+// tasks lists the solver for part p followed by its variants
+func (d Day) tasks(p int) []task {
+	var ts []task
+	if s := d.Solver(p); s != nil {
+		ts = append(ts, task{label: fmt.Sprintf("Part %d", p), part: p, solve: s})
+	}
+	for _, v := range d.Variants {
+		if v.Part == p {
+			ts = append(ts, task{label: fmt.Sprintf("Part %d (%s)", p, v.Name), part: p, variant: v.Name, solve: v.Solve})
+		}
+	}
+	return ts
+}
+
+// runLabelled runs the task with pprof labels and a trace region naming
+// the day, part and variant, so profiles can be filtered per solver.
+func (t task) runLabelled(day int, lines []string) (answer int, elapsed time.Duration, err error) {
+	labels := []string{"day", fmt.Sprintf("%02d", day), "part", strconv.Itoa(t.part)}
+	if t.variant != "" {
+		labels = append(labels, "variant", t.variant)
+	}
+	pprof.Do(context.Background(), pprof.Labels(labels...), func(ctx context.Context) {
+		trace.WithRegion(ctx, fmt.Sprintf("day%02d %s", day, t.label), func() {
+			answer, elapsed, err = t.run(lines)
+		})
+	})
+	return answer, elapsed, err
+}
+
+// run calls the solver, turning a panic into an error
+func (t task) run(lines []string) (answer int, elapsed time.Duration, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	start := time.Now()
+	answer, err = t.solve(lines)
+	elapsed = time.Since(start)
+	return answer, elapsed, err
+}
@@ -0,0 +1,184 @@
+package aoc
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testDay = Day{
+	Number: 99,
+	Part1:  func(lines []string) (int, error) { return len(lines), nil },
+	Part2:  func(lines []string) (int, error) { return 2 * len(lines), nil },
+	Variants: []Variant{
+		{Name: "slow", Part: 1, Solve: func(lines []string) (int, error) { return len(lines), nil }},
+	},
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		part   string
+		want   string
+		status int
+	}{
+		{"both", "both", "Part 1: 3\nPart 1 (slow): 3\nPart 2: 6\n", 0},
+		{"part1", "1", "Part 1: 3\nPart 1 (slow): 3\n", 0},
+		{"part2", "2", "Part 2: 6\n", 0},
+		{"bad part", "3", "", 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := testDay.Run(Options{Part: tc.part}, strings.NewReader("a\nb\nc\n"), &stdout, &stderr)
+			if status != tc.status {
+				t.Errorf("status = %d, want %d (stderr: %s)", status, tc.status, stderr.String())
+			}
+			if got := stdout.String(); got != tc.want {
+				t.Errorf("stdout = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunInputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("x\ny\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if status := testDay.Run(Options{Input: path, Part: "2"}, nil, &stdout, &stderr); status != 0 {
+		t.Fatalf("status = %d, stderr: %s", status, stderr.String())
+	}
+	if got, want := stdout.String(), "Part 2: 4\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+
+	status := testDay.Run(Options{Input: filepath.Join(t.TempDir(), "missing.txt")}, nil, &stdout, &stderr)
+	if status != 1 {
+		t.Errorf("missing input: status = %d, want 1", status)
+	}
+}
+
+func TestRunPanic(t *testing.T) {
+	d := Day{
+		Number: 99,
+		Part1:  func(lines []string) (int, error) { return len(lines[5]), nil },
+		Part2:  func(lines []string) (int, error) { return 42, nil },
+	}
+	var stdout, stderr bytes.Buffer
+	status := d.Run(Options{}, strings.NewReader("a\n"), &stdout, &stderr)
+	if status != 1 {
+		t.Errorf("status = %d, want 1", status)
+	}
+	if got, want := stdout.String(), "Part 2: 42\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if !strings.Contains(stderr.String(), "Part 1: panic:") {
+		t.Errorf("stderr = %q, want panic report for part 1", stderr.String())
+	}
+}
+
+func TestRunProfiles(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{
+		Part:       "1",
+		CPUProfile: filepath.Join(dir, "cpu.pprof"),
+		MemProfile: filepath.Join(dir, "mem.pprof"),
+		Trace:      filepath.Join(dir, "trace.out"),
+	}
+	var stdout, stderr bytes.Buffer
+	if status := testDay.Run(opts, strings.NewReader("a\n"), &stdout, &stderr); status != 0 {
+		t.Fatalf("status = %d, stderr: %s", status, stderr.String())
+	}
+	for _, path := range []string{opts.CPUProfile, opts.MemProfile, opts.Trace} {
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Size() == 0 {
+			t.Errorf("%s is empty", filepath.Base(path))
+		}
+	}
+	data, err := os.ReadFile(opts.Trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, region := range []string{"day99 Part 1", "day99 Part 1 (slow)"} {
+		if !bytes.Contains(data, []byte(region)) {
+			t.Errorf("trace has no region %q", region)
+		}
+	}
+	if bytes.Contains(data, []byte("day99 Part 2")) {
+		t.Error("trace covers part 2, which was not selected")
+	}
+}
+
+func TestRunProfileError(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	opts := Options{CPUProfile: filepath.Join(t.TempDir(), "missing", "cpu.pprof")}
+	if status := testDay.Run(opts, strings.NewReader("a\n"), &stdout, &stderr); status != 1 {
+		t.Errorf("status = %d, want 1", status)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want nothing before profiling starts", stdout.String())
+	}
+}
+
+func TestStartProfilingFailureStopsStarted(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{
+		CPUProfile: filepath.Join(dir, "cpu.pprof"),
+		MemProfile: filepath.Join(dir, "mem.pprof"),
+		Trace:      filepath.Join(dir, "missing", "trace.out"),
+	}
+	if _, err := startProfiling(opts); err == nil {
+		t.Fatal("startProfiling succeeded, want error")
+	}
+	if _, err := os.Stat(opts.MemProfile); !os.IsNotExist(err) {
+		t.Errorf("allocation profile written after failed start: %v", err)
+	}
+	// The CPU profile must be stopped, or this second start fails
+	p, err := startProfiling(Options{CPUProfile: filepath.Join(dir, "cpu2.pprof")})
+	if err != nil {
+		t.Fatalf("restart after failure: %v", err)
+	}
+	if err := p.stop(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTool(t *testing.T) {
+	d := Day{Tools: []Tool{{Name: "a"}, {Name: "b", Usage: "[n]"}}}
+	if tool, ok := d.Tool("b"); !ok || tool.Usage != "[n]" {
+		t.Errorf("Tool(b) = %v, %v; want the second tool", tool, ok)
+	}
+	if _, ok := d.Tool("c"); ok {
+		t.Error("Tool(c) found a tool that does not exist")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	defer func() { delete(registry, 98); delete(registry, 97) }()
+	Register(Day{Number: 98})
+	Register(Day{Number: 97})
+	if _, ok := Lookup(98); !ok {
+		t.Error("Lookup(98) not found")
+	}
+	if _, ok := Lookup(96); ok {
+		t.Error("Lookup(96) found an unregistered day")
+	}
+	days := Days()
+	if len(days) != 2 || days[0].Number != 97 || days[1].Number != 98 {
+		t.Errorf("Days() = %v, want days 97 and 98 in order", days)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("duplicate Register did not panic")
+		}
+	}()
+	Register(Day{Number: 98})
+}
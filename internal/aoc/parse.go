@@ -0,0 +1,101 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError reports malformed puzzle input. Line and Column are 1-based;
+// zero means the position is unknown.
+type ParseError struct {
+	Day    int
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		return fmt.Sprintf("day %d: line %d, column %d: %s", e.Day, e.Line, e.Column, e.Msg)
+	case e.Line > 0:
+		return fmt.Sprintf("day %d: line %d: %s", e.Day, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("day %d: %s", e.Day, e.Msg)
+}
+
+// ParseErrorf builds a ParseError at the given 1-based line and column
+func ParseErrorf(day, line, column int, format string, args ...any) *ParseError {
+	return &ParseError{Day: day, Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// WithLine fills in the line of a ParseError produced by a single-line
+// parser. Other errors, and ParseErrors that already have a line, pass through.
+func WithLine(err error, line int) error {
+	var pe *ParseError
+	if errors.As(err, &pe) && pe.Line == 0 {
+		cp := *pe
+		cp.Line = line
+		return &cp
+	}
+	return err
+}
+
+// Atoi parses s as a decimal integer found at the given line and column
+func Atoi(day, line, column int, s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, ParseErrorf(day, line, column, "invalid number %q", s)
+	}
+	return n, nil
+}
+
+// Field is a whitespace-separated token and the 1-based column it starts at
+type Field struct {
+	Text   string
+	Column int
+}
+
+// Fields splits s around runs of spaces and tabs like strings.Fields,
+// keeping the column of every field for error reporting.
+func Fields(s string) []Field {
+	var fields []Field
+	start := -1
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == ' ' || s[i] == '\t' {
+			if start >= 0 {
+				fields = append(fields, Field{s[start:i], start + 1})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	return fields
+}
+
+// Describe formats err for the terminal. A ParseError pointing into lines
+// is followed by the offending line with a caret under the column.
+func Describe(err error, lines []string) string {
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line < 1 || pe.Line > len(lines) {
+		return err.Error()
+	}
+	line := lines[pe.Line-1]
+	gutter := strconv.Itoa(pe.Line)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n %s | %s", err, gutter, line)
+	if pe.Column > 0 && pe.Column <= len(line)+1 {
+		// Keep tabs so the caret lines up with the echoed text
+		pad := []byte(line[:pe.Column-1])
+		for i, c := range pad {
+			if c != '\t' {
+				pad[i] = ' '
+			}
+		}
+		fmt.Fprintf(&b, "\n %s | %s^", strings.Repeat(" ", len(gutter)), pad)
+	}
+	return b.String()
+}
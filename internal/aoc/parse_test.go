@@ -0,0 +1,103 @@
+package aoc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  *ParseError
+		want string
+	}{
+		{ParseErrorf(5, 3, 7, "invalid number %q", "1x"), `day 5: line 3, column 7: invalid number "1x"`},
+		{ParseErrorf(12, 4, 0, "missing colon"), "day 12: line 4: missing colon"},
+		{ParseErrorf(12, 0, 0, "no regions"), "day 12: no regions"},
+	}
+	for _, tc := range tests {
+		if got := tc.err.Error(); got != tc.want {
+			t.Errorf("Error() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestWithLine(t *testing.T) {
+	err := WithLine(fmt.Errorf("machine: %w", ParseErrorf(10, 0, 4, "bad")), 9)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line != 9 || pe.Column != 4 {
+		t.Errorf("WithLine = %v, want line 9 column 4", err)
+	}
+	if err := WithLine(ParseErrorf(10, 2, 1, "bad"), 9); err.(*ParseError).Line != 2 {
+		t.Errorf("WithLine replaced an existing line: %v", err)
+	}
+	plain := errors.New("plain")
+	if err := WithLine(plain, 9); err != plain {
+		t.Errorf("WithLine changed a non-parse error: %v", err)
+	}
+}
+
+func TestFields(t *testing.T) {
+	got := Fields("  12x5:  1 0\t3")
+	want := []Field{{"12x5:", 3}, {"1", 10}, {"0", 12}, {"3", 14}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Fields = %v, want %v", got, want)
+	}
+	if got := Fields("   "); len(got) != 0 {
+		t.Errorf("Fields(blank) = %v, want none", got)
+	}
+}
+
+func TestAtoi(t *testing.T) {
+	if n, err := Atoi(1, 1, 1, "42"); n != 42 || err != nil {
+		t.Errorf("Atoi(42) = %d, %v", n, err)
+	}
+	_, err := Atoi(1, 2, 3, "4x")
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line != 2 || pe.Column != 3 {
+		t.Errorf("Atoi(4x) error = %v, want ParseError at 2:3", err)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	lines := []string{"3-5", "10-1x", "\tab"}
+	got := Describe(ParseErrorf(5, 2, 4, "invalid number %q", "1x"), lines)
+	want := "day 5: line 2, column 4: invalid number \"1x\"\n" +
+		" 2 | 10-1x\n" +
+		"   |    ^"
+	if got != want {
+		t.Errorf("Describe =\n%s\nwant\n%s", got, want)
+	}
+
+	got = Describe(ParseErrorf(5, 3, 3, "bad"), lines)
+	if !strings.HasSuffix(got, "\n   | \t ^") {
+		t.Errorf("Describe did not keep the tab before the caret:\n%q", got)
+	}
+
+	if got := Describe(ParseErrorf(5, 9, 1, "bad"), lines); got != "day 5: line 9, column 1: bad" {
+		t.Errorf("Describe with line out of range = %q", got)
+	}
+	if got := Describe(errors.New("boom"), lines); got != "boom" {
+		t.Errorf("Describe(plain) = %q", got)
+	}
+}
+
+func TestRunReportsParseError(t *testing.T) {
+	d := Day{
+		Number: 99,
+		Part1: func(lines []string) (int, error) {
+			return 0, ParseErrorf(99, 2, 3, "unexpected %q", lines[1][2:3])
+		},
+	}
+	var stdout, stderr bytes.Buffer
+	status := d.Run(Options{Part: "1"}, strings.NewReader("ok\nabcd\n"), &stdout, &stderr)
+	if status != 1 {
+		t.Errorf("status = %d, want 1", status)
+	}
+	want := "Part 1: day 99: line 2, column 3: unexpected \"c\"\n 2 | abcd\n   |   ^\n"
+	if got := stderr.String(); got != want {
+		t.Errorf("stderr =\n%s\nwant\n%s", got, want)
+	}
+}
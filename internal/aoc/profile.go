@@ -0,0 +1,85 @@
+package aoc
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// profiler captures the profiles requested in Options around the solver runs
+type profiler struct {
+	cpu     *os.File
+	trace   *os.File
+	memPath string
+}
+
+// startProfiling begins CPU profiling and execution tracing as requested
+func startProfiling(opts Options) (*profiler, error) {
+	p := &profiler{memPath: opts.MemProfile}
+	if opts.CPUProfile != "" {
+		f, err := os.Create(opts.CPUProfile)
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		p.cpu = f
+	}
+	if opts.Trace != "" {
+		f, err := os.Create(opts.Trace)
+		if err != nil {
+			p.close()
+			return nil, err
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			p.close()
+			return nil, err
+		}
+		p.trace = f
+	}
+	return p, nil
+}
+
+// stop ends the running profiles and writes the allocation profile
+func (p *profiler) stop() error {
+	err := p.close()
+	if p.memPath != "" {
+		err = errors.Join(err, writeHeapProfile(p.memPath))
+	}
+	return err
+}
+
+// close ends only the profiles that were started
+func (p *profiler) close() error {
+	var errs []error
+	if p.cpu != nil {
+		pprof.StopCPUProfile()
+		errs = append(errs, p.cpu.Close())
+	}
+	if p.trace != nil {
+		trace.Stop()
+		errs = append(errs, p.trace.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// writeHeapProfile writes the allocs profile, which counts every sampled
+// allocation since the process started, input reading included
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	// Flush recently freed objects into the profile
+	runtime.GC()
+	if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,58 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+var registry = map[int]Day{}
+
+// Register makes d available to Lookup and Days. It is meant to be called
+// from a day package's init function and panics on a duplicate day number.
+func Register(d Day) {
+	if _, dup := registry[d.Number]; dup {
+		panic(fmt.Sprintf("aoc: day %d registered twice", d.Number))
+	}
+	registry[d.Number] = d
+}
+
+// Lookup returns the registered day with the given number
+func Lookup(n int) (Day, bool) {
+	d, ok := registry[n]
+	return d, ok
+}
+
+// Days returns every registered day in calendar order
+func Days() []Day {
+	days := make([]Day, 0, len(registry))
+	for _, d := range registry {
+		days = append(days, d)
+	}
+	slices.SortFunc(days, func(a, b Day) int { return a.Number - b.Number })
+	return days
+}
+
+// FindRoot walks up from the working directory to the directory holding go.mod
+func FindRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("go.mod not found above working directory")
+		}
+		dir = parent
+	}
+}
+
+// InputPath returns the conventional location of day n's input under root
+func InputPath(root string, n int) string {
+	return filepath.Join(root, "inputs", fmt.Sprintf("day%02d.txt", n))
+}
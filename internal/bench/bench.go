@@ -0,0 +1,259 @@
+// Package bench runs solver benchmarks programmatically, stores the results
+// as JSON and compares runs with a significance test.
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"aoc2025/internal/aoc"
+)
+
+// Sample is the outcome of one testing.Benchmark run
+type Sample struct {
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+}
+
+// Result holds every sample taken for one named benchmark
+type Result struct {
+	Name    string   `json:"name"`
+	Samples []Sample `json:"samples"`
+}
+
+// Report is a complete benchmark run as written to disk
+type Report struct {
+	Time      time.Time `json:"time"`
+	GoVersion string    `json:"go_version"`
+	GOOS      string    `json:"goos"`
+	GOARCH    string    `json:"goarch"`
+	Results   []Result  `json:"results"`
+}
+
+// NewReport returns an empty report stamped with the current environment
+func NewReport() *Report {
+	return &Report{
+		Time:      time.Now().UTC(),
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+	}
+}
+
+// Run benchmarks f count times and returns the samples under name
+func Run(name string, f func(b *testing.B), count int) Result {
+	r := Result{Name: name}
+	for range count {
+		br := testing.Benchmark(f)
+		if br.N == 0 {
+			// The benchmark failed or was skipped
+			break
+		}
+		r.Samples = append(r.Samples, Sample{
+			NsPerOp:     float64(br.T.Nanoseconds()) / float64(br.N),
+			BytesPerOp:  br.AllocedBytesPerOp(),
+			AllocsPerOp: br.AllocsPerOp(),
+		})
+	}
+	return r
+}
+
+// Mean returns the average of field over the samples
+func (r Result) Mean(field func(Sample) float64) float64 {
+	if len(r.Samples) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, s := range r.Samples {
+		sum += field(s)
+	}
+	return sum / float64(len(r.Samples))
+}
+
+// NsPerOp, BytesPerOp and AllocsPerOp select a field for Mean
+func NsPerOp(s Sample) float64     { return s.NsPerOp }
+func BytesPerOp(s Sample) float64  { return float64(s.BytesPerOp) }
+func AllocsPerOp(s Sample) float64 { return float64(s.AllocsPerOp) }
+
+// Find returns the result with the given name
+func (r *Report) Find(name string) (Result, bool) {
+	i := slices.IndexFunc(r.Results, func(res Result) bool { return res.Name == name })
+	if i < 0 {
+		return Result{}, false
+	}
+	return r.Results[i], true
+}
+
+// WriteFile stores the report as indented JSON, replacing path atomically
+func (r *Report) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return aoc.WriteFileAtomic(path, append(data, '\n'))
+}
+
+// ReadFile loads a report written by WriteFile
+func ReadFile(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// Delta compares one benchmark between a baseline and a new run
+type Delta struct {
+	Name       string
+	Old, New   float64 // mean ns/op
+	Change     float64 // relative change in ns/op, 0.1 = 10% slower
+	P          float64 // two-sided p-value of Welch's t-test
+	Regression bool    // significantly slower by more than the threshold
+	Improved   bool    // significantly faster by more than the threshold
+}
+
+// Compare matches the results of cur against base by name. A change is
+// flagged when its p-value is below alpha and its magnitude exceeds threshold.
+func Compare(base, cur *Report, alpha, threshold float64) []Delta {
+	var deltas []Delta
+	for _, r := range cur.Results {
+		old, ok := base.Find(r.Name)
+		if !ok || len(old.Samples) == 0 || len(r.Samples) == 0 {
+			continue
+		}
+		d := Delta{Name: r.Name, Old: old.Mean(NsPerOp), New: r.Mean(NsPerOp)}
+		if d.Old > 0 {
+			d.Change = (d.New - d.Old) / d.Old
+		}
+		d.P = WelchTTest(values(old), values(r))
+		significant := d.P < alpha
+		d.Regression = significant && d.Change > threshold
+		d.Improved = significant && d.Change < -threshold
+		deltas = append(deltas, d)
+	}
+	return deltas
+}
+
+func values(r Result) []float64 {
+	v := make([]float64, len(r.Samples))
+	for i, s := range r.Samples {
+		v[i] = s.NsPerOp
+	}
+	return v
+}
+
+// WelchTTest returns the two-sided p-value for the hypothesis that a and b
+// have the same mean, without assuming equal variances. With fewer than two
+// samples on either side nothing can be concluded and it returns 1.
+func WelchTTest(a, b []float64) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 1
+	}
+	ma, va := meanVar(a)
+	mb, vb := meanVar(b)
+	na, nb := float64(len(a)), float64(len(b))
+	sa, sb := va/na, vb/nb
+	if sa+sb == 0 {
+		// Identical samples on both sides: either exactly equal or certainly different
+		if ma == mb {
+			return 1
+		}
+		return 0
+	}
+	t := (ma - mb) / math.Sqrt(sa+sb)
+	df := (sa + sb) * (sa + sb) / (sa*sa/(na-1) + sb*sb/(nb-1))
+	return studentTwoSided(t, df)
+}
+
+func meanVar(x []float64) (mean, variance float64) {
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+	for _, v := range x {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, variance / float64(len(x)-1)
+}
+
+// studentTwoSided is P(|T| >= |t|) for Student's t with df degrees of freedom
+func studentTwoSided(t, df float64) float64 {
+	return regIncBeta(df/2, 0.5, df/(df+t*t))
+}
+
+// regIncBeta is the regularized incomplete beta function I_x(a, b)
+func regIncBeta(a, b, x float64) float64 {
+	switch {
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	// The continued fraction converges fast only below the mean; use symmetry above it
+	if x < (a+1)/(a+b+2) {
+		return front * betaCF(a, b, x) / a
+	}
+	return 1 - front*betaCF(b, a, 1-x)/b
+}
+
+// betaCF evaluates the continued fraction for I_x(a, b) with Lentz's method
+func betaCF(a, b, x float64) float64 {
+	const (
+		maxIter = 300
+		eps     = 1e-15
+		tiny    = 1e-300
+	)
+	qab, qap, qam := a+b, a+1, a-1
+	c, d := 1.0, 1-qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIter; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,130 @@
+package bench
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestRegIncBeta(t *testing.T) {
+	for _, x := range []float64{0.01, 0.2, 0.5, 0.77, 0.99} {
+		// Closed forms: I_x(a, 1) = x^a and I_x(1, b) = 1 - (1-x)^b
+		if got, want := regIncBeta(3.5, 1, x), math.Pow(x, 3.5); !approx(got, want) {
+			t.Errorf("I_%v(3.5, 1) = %v, want %v", x, got, want)
+		}
+		if got, want := regIncBeta(1, 2.5, x), 1-math.Pow(1-x, 2.5); !approx(got, want) {
+			t.Errorf("I_%v(1, 2.5) = %v, want %v", x, got, want)
+		}
+	}
+}
+
+func TestStudentTwoSided(t *testing.T) {
+	for _, tv := range []float64{0, 0.3, 1, 2.5, 12} {
+		// df=1 is the Cauchy distribution, df=2 has a closed form too
+		if got, want := studentTwoSided(tv, 1), 1-2/math.Pi*math.Atan(tv); !approx(got, want) {
+			t.Errorf("df=1 t=%v: p = %v, want %v", tv, got, want)
+		}
+		if got, want := studentTwoSided(tv, 2), 1-tv/math.Sqrt(2+tv*tv); !approx(got, want) {
+			t.Errorf("df=2 t=%v: p = %v, want %v", tv, got, want)
+		}
+	}
+	// Large df approaches the normal distribution: P(|Z| >= 1.96) ~ 0.05
+	if got := studentTwoSided(1.959963984540054, 1e7); math.Abs(got-0.05) > 1e-5 {
+		t.Errorf("df=1e7 t=1.96: p = %v, want ~0.05", got)
+	}
+}
+
+func TestWelchTTest(t *testing.T) {
+	same := []float64{100, 101, 99, 100, 100}
+	if p := WelchTTest(same, same); !approx(p, 1) {
+		t.Errorf("identical samples: p = %v, want 1", p)
+	}
+	slower := []float64{120, 121, 119, 120, 121}
+	if p := WelchTTest(same, slower); p > 1e-6 {
+		t.Errorf("clearly different samples: p = %v, want tiny", p)
+	}
+	noisy := []float64{80, 130, 95, 110, 90}
+	if p := WelchTTest(same, noisy); p < 0.5 {
+		t.Errorf("noisy samples with the same mean: p = %v, want large", p)
+	}
+	if p := WelchTTest([]float64{1}, slower); p != 1 {
+		t.Errorf("single sample: p = %v, want 1", p)
+	}
+	if p := WelchTTest([]float64{5, 5, 5}, []float64{6, 6, 6}); p != 0 {
+		t.Errorf("constant but different samples: p = %v, want 0", p)
+	}
+}
+
+func result(name string, ns ...float64) Result {
+	r := Result{Name: name}
+	for _, v := range ns {
+		r.Samples = append(r.Samples, Sample{NsPerOp: v})
+	}
+	return r
+}
+
+func TestCompare(t *testing.T) {
+	base := &Report{Results: []Result{
+		result("Day01/Part1", 100, 101, 99, 100, 100),
+		result("Day01/Part2", 100, 101, 99, 100, 100),
+		result("Day02/Part1", 100, 101, 99, 100, 100),
+		result("Day03/Part1", 100, 101, 99, 100, 100),
+	}}
+	cur := &Report{Results: []Result{
+		result("Day01/Part1", 150, 151, 149, 150, 150), // regression
+		result("Day01/Part2", 101, 102, 100, 101, 101), // significant but under threshold
+		result("Day02/Part1", 50, 51, 49, 50, 50),      // improvement
+		result("Day04/Part1", 10, 10, 10),              // not in baseline
+	}}
+	deltas := Compare(base, cur, 0.05, 0.05)
+	if len(deltas) != 3 {
+		t.Fatalf("got %d deltas, want 3", len(deltas))
+	}
+	want := []struct {
+		name                 string
+		regression, improved bool
+	}{
+		{"Day01/Part1", true, false},
+		{"Day01/Part2", false, false},
+		{"Day02/Part1", false, true},
+	}
+	for i, w := range want {
+		d := deltas[i]
+		if d.Name != w.name || d.Regression != w.regression || d.Improved != w.improved {
+			t.Errorf("delta %d = %+v, want %s regression=%v improved=%v", i, d, w.name, w.regression, w.improved)
+		}
+	}
+	if !approx(deltas[0].Change, 0.5) {
+		t.Errorf("Day01/Part1 change = %v, want 0.5", deltas[0].Change)
+	}
+}
+
+func TestRunAndRoundTrip(t *testing.T) {
+	r := Run("alloc", func(b *testing.B) {
+		for b.Loop() {
+			_ = make([]byte, 64)
+		}
+	}, 2)
+	if len(r.Samples) != 2 {
+		t.Fatalf("got %d samples, want 2", len(r.Samples))
+	}
+
+	rep := NewReport()
+	rep.Results = append(rep.Results, r)
+	path := filepath.Join(t.TempDir(), "bench.json")
+	if err := rep.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, ok := got.Find("alloc")
+	if !ok || len(res.Samples) != 2 || res.Samples[0] != r.Samples[0] {
+		t.Errorf("round trip = %+v, want %+v", res, r)
+	}
+}
@@ -0,0 +1,263 @@
+// Package dlx solves exact cover problems with Knuth's Algorithm X on
+// dancing links.
+//
+// Columns 0..primary-1 are primary and must be covered exactly once, or
+// as many times as SetMultiplicity says. The remaining columns are
+// secondary: they may be covered at most once, so rows that share one
+// exclude each other without forcing either.
+package dlx
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Matrix is a sparse 0/1 matrix linked in both directions. Node 0 is the
+// root, nodes 1..columns are the column headers and the rest are the 1s.
+type Matrix struct {
+	primary, columns int
+
+	left, right, up, down []int
+	col, row              []int // header and row of each node
+	size                  []int // number of live 1s in each column, by header
+	need                  []int // covers still required of each primary column, by header
+
+	rows  int
+	nodes int // search steps taken by the last Solutions run
+}
+
+// New returns an empty matrix with the given numbers of primary and
+// secondary columns
+func New(primary, secondary int) *Matrix {
+	n := primary + secondary
+	m := &Matrix{primary: primary, columns: n}
+	for i := 0; i <= n; i++ {
+		m.left = append(m.left, i)
+		m.right = append(m.right, i)
+		m.up = append(m.up, i)
+		m.down = append(m.down, i)
+		m.col = append(m.col, i)
+		m.row = append(m.row, -1)
+	}
+	m.size = make([]int, n+1)
+	m.need = make([]int, n+1)
+	for h := 1; h <= primary; h++ {
+		m.need[h] = 1
+	}
+	// Only primary headers join the root's list, so only they are chosen
+	for h := 1; h <= primary; h++ {
+		m.left[h] = h - 1
+		m.right[h-1] = h
+	}
+	m.left[0] = primary
+	m.right[primary] = 0
+	return m
+}
+
+// SetMultiplicity requires primary column col to be covered by exactly n
+// rows. The rows chosen for it form a set: each set is found once, not once
+// per order.
+func (m *Matrix) SetMultiplicity(col, n int) {
+	if col < 0 || col >= m.primary {
+		panic(fmt.Sprintf("dlx: column %d is not primary", col))
+	}
+	if n < 1 {
+		panic(fmt.Sprintf("dlx: multiplicity %d of column %d is below 1", n, col))
+	}
+	m.need[col+1] = n
+}
+
+// AddRow adds a row with 1s in the given columns and returns its index.
+// Rows are numbered from 0 in the order they are added.
+func (m *Matrix) AddRow(cols ...int) int {
+	seen := make(map[int]bool, len(cols))
+	for _, c := range cols {
+		if c < 0 || c >= m.columns {
+			panic(fmt.Sprintf("dlx: column %d out of range [0, %d)", c, m.columns))
+		}
+		if seen[c] {
+			panic(fmt.Sprintf("dlx: column %d repeated in row", c))
+		}
+		seen[c] = true
+	}
+
+	r := m.rows
+	m.rows++
+	first := len(m.col)
+	for i, c := range cols {
+		h := c + 1
+		x := len(m.col)
+		m.col = append(m.col, h)
+		m.row = append(m.row, r)
+		// Append at the bottom of the column
+		m.up = append(m.up, m.up[h])
+		m.down = append(m.down, h)
+		m.down[m.up[h]] = x
+		m.up[h] = x
+		m.size[h]++
+		// Link into the row's circular list
+		if i == 0 {
+			m.left = append(m.left, x)
+			m.right = append(m.right, x)
+		} else {
+			m.left = append(m.left, x-1)
+			m.right = append(m.right, first)
+			m.right[x-1] = x
+			m.left[first] = x
+		}
+	}
+	return r
+}
+
+// Rows returns the number of rows added
+func (m *Matrix) Rows() int {
+	return m.rows
+}
+
+// Nodes returns the number of rows tried by the last search
+func (m *Matrix) Nodes() int {
+	return m.nodes
+}
+
+func (m *Matrix) cover(h int) {
+	m.right[m.left[h]] = m.right[h]
+	m.left[m.right[h]] = m.left[h]
+	for i := m.down[h]; i != h; i = m.down[i] {
+		for j := m.right[i]; j != i; j = m.right[j] {
+			m.down[m.up[j]] = m.down[j]
+			m.up[m.down[j]] = m.up[j]
+			m.size[m.col[j]]--
+		}
+	}
+}
+
+// hide unlinks every 1 of the row holding node i from its column
+func (m *Matrix) hide(i int) {
+	j := i
+	for {
+		m.down[m.up[j]] = m.down[j]
+		m.up[m.down[j]] = m.up[j]
+		m.size[m.col[j]]--
+		if j = m.right[j]; j == i {
+			return
+		}
+	}
+}
+
+// unhide undoes hide(i)
+func (m *Matrix) unhide(i int) {
+	j := i
+	for {
+		j = m.left[j]
+		m.size[m.col[j]]++
+		m.down[m.up[j]] = j
+		m.up[m.down[j]] = j
+		if j == i {
+			return
+		}
+	}
+}
+
+func (m *Matrix) uncover(h int) {
+	for i := m.up[h]; i != h; i = m.up[i] {
+		for j := m.left[i]; j != i; j = m.left[j] {
+			m.size[m.col[j]]++
+			m.down[m.up[j]] = j
+			m.up[m.down[j]] = j
+		}
+	}
+	m.right[m.left[h]] = h
+	m.left[m.right[h]] = h
+}
+
+// Solutions yields every exact cover as the indices of the chosen rows.
+// The slice is reused between solutions; copy it to keep it. At each level
+// the primary column with the fewest choices left is branched on.
+func (m *Matrix) Solutions() iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		m.nodes = 0
+		var chosen []int
+		var search func() bool
+		// choose takes row i and searches on; the row's primary column has
+		// already been dealt with by the caller
+		choose := func(i int) bool {
+			m.nodes++
+			chosen = append(chosen, m.row[i])
+			for j := m.right[i]; j != i; j = m.right[j] {
+				m.cover(m.col[j])
+			}
+			more := search()
+			for j := m.left[i]; j != i; j = m.left[j] {
+				m.uncover(m.col[j])
+			}
+			chosen = chosen[:len(chosen)-1]
+			return more
+		}
+		search = func() bool {
+			if m.right[0] == 0 {
+				return yield(chosen)
+			}
+			best := -1
+			for h := m.right[0]; h != 0; h = m.right[h] {
+				if best < 0 || m.size[h]-m.need[h] < m.size[best]-m.need[best] {
+					best = h
+				}
+			}
+			if m.size[best] < m.need[best] {
+				return true
+			}
+
+			if m.need[best] == 1 {
+				m.cover(best)
+				defer m.uncover(best)
+				for i := m.down[best]; i != best; i = m.down[i] {
+					if !choose(i) {
+						return false
+					}
+				}
+				return true
+			}
+
+			// The column stays open for its remaining covers. Each row tried
+			// is hidden from the rest of this level, so a set of rows is only
+			// chosen in column order.
+			m.need[best]--
+			defer func() { m.need[best]++ }()
+			var tried []int
+			defer func() {
+				for k := len(tried) - 1; k >= 0; k-- {
+					m.unhide(tried[k])
+				}
+			}()
+			for i := m.down[best]; i != best; {
+				next := m.down[i]
+				m.hide(i)
+				tried = append(tried, i)
+				// hide took the row out of best too; choose only covers the rest
+				if !choose(i) {
+					return false
+				}
+				i = next
+			}
+			return true
+		}
+		search()
+	}
+}
+
+// First returns one exact cover, if any
+func (m *Matrix) First() ([]int, bool) {
+	for rows := range m.Solutions() {
+		return append([]int(nil), rows...), true
+	}
+	return nil, false
+}
+
+// Count returns the number of exact covers
+func (m *Matrix) Count() int {
+	n := 0
+	for range m.Solutions() {
+		n++
+	}
+	return n
+}
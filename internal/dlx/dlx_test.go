@@ -0,0 +1,124 @@
+package dlx
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestKnuthExample(t *testing.T) {
+	// The example from Knuth's "Dancing Links" paper
+	m := New(7, 0)
+	m.AddRow(2, 4, 5)
+	m.AddRow(0, 3, 6)
+	m.AddRow(1, 2, 5)
+	m.AddRow(0, 3)
+	m.AddRow(1, 6)
+	m.AddRow(3, 4, 6)
+
+	rows, ok := m.First()
+	slices.Sort(rows)
+	if !ok || !slices.Equal(rows, []int{0, 3, 4}) {
+		t.Errorf("First() = %v, %v; want [0 3 4]", rows, ok)
+	}
+	if n := m.Count(); n != 1 {
+		t.Errorf("Count() = %d, want 1", n)
+	}
+	if m.Nodes() == 0 {
+		t.Error("Nodes() = 0 after a search")
+	}
+}
+
+func TestNoSolution(t *testing.T) {
+	m := New(3, 0)
+	m.AddRow(0, 1)
+	m.AddRow(1, 2)
+	if _, ok := m.First(); ok {
+		t.Error("First() found a cover that does not exist")
+	}
+	if empty := New(0, 2); empty.Count() != 1 {
+		t.Error("a matrix with no primary columns should have exactly the empty cover")
+	}
+}
+
+// queens builds n-queens: ranks and files are primary, diagonals secondary
+func queens(n int) *Matrix {
+	m := New(2*n, 2*(2*n-1))
+	for r := range n {
+		for c := range n {
+			m.AddRow(r, n+c, 2*n+r+c, 2*n+2*n-1+r-c+n-1)
+		}
+	}
+	return m
+}
+
+func TestSecondaryColumns(t *testing.T) {
+	for n, want := range map[int]int{1: 1, 2: 0, 4: 2, 6: 4, 8: 92} {
+		if got := queens(n).Count(); got != want {
+			t.Errorf("%d queens: Count() = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestStopEarly(t *testing.T) {
+	m := queens(8)
+	seen := 0
+	for rows := range m.Solutions() {
+		if len(rows) != 8 {
+			t.Fatalf("solution has %d rows, want 8", len(rows))
+		}
+		seen++
+		if seen == 3 {
+			break
+		}
+	}
+	// The links must be restored so a second search sees everything
+	if got := m.Count(); got != 92 {
+		t.Errorf("Count() after an early stop = %d, want 92", got)
+	}
+}
+
+func TestMultiplicity(t *testing.T) {
+	// Two dominoes on a 1x4 strip, as one primary column needing two rows
+	m := New(1, 4)
+	m.SetMultiplicity(0, 2)
+	for c := range 3 {
+		m.AddRow(0, 1+c, 2+c)
+	}
+	var got [][]int
+	for rows := range m.Solutions() {
+		got = append(got, slices.Sorted(slices.Values(rows)))
+	}
+	if len(got) != 1 || !slices.Equal(got[0], []int{0, 2}) {
+		t.Errorf("two dominoes in 1x4 = %v, want [[0 2]]", got)
+	}
+
+	// Each set of rows once, not once per order
+	only := New(1, 0)
+	only.SetMultiplicity(0, 2)
+	for range 4 {
+		only.AddRow(0)
+	}
+	if n := only.Count(); n != 6 {
+		t.Errorf("2 of 4 rows: Count() = %d, want 6", n)
+	}
+	short := New(1, 0)
+	short.SetMultiplicity(0, 3)
+	short.AddRow(0)
+	short.AddRow(0)
+	if n := short.Count(); n != 0 {
+		t.Errorf("3 of 2 rows: Count() = %d, want 0", n)
+	}
+}
+
+func TestAddRowPanics(t *testing.T) {
+	for name, cols := range map[string][]int{"range": {0, 3}, "repeat": {1, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: AddRow(%v) did not panic", name, cols)
+				}
+			}()
+			New(2, 1).AddRow(cols...)
+		}()
+	}
+}
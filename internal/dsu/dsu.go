@@ -0,0 +1,190 @@
+// Package dsu provides disjoint-set union over the elements 0..n-1.
+package dsu
+
+// DSU is a union-find with path compression and union by size
+type DSU struct {
+	parent []int
+	size   []int
+	count  int
+}
+
+// New returns n singleton sets
+func New(n int) *DSU {
+	d := &DSU{parent: make([]int, n), size: make([]int, n), count: n}
+	for i := range n {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+	return d
+}
+
+// Len returns the number of elements
+func (d *DSU) Len() int {
+	return len(d.parent)
+}
+
+// Find returns the representative of x's set
+func (d *DSU) Find(x int) int {
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// Point the whole path at the root
+	for d.parent[x] != root {
+		d.parent[x], x = root, d.parent[x]
+	}
+	return root
+}
+
+// Union merges the sets of x and y and reports whether they were separate
+func (d *DSU) Union(x, y int) bool {
+	px, py := d.Find(x), d.Find(y)
+	if px == py {
+		return false
+	}
+	// Attach the smaller tree under the larger
+	if d.size[px] < d.size[py] {
+		px, py = py, px
+	}
+	d.parent[py] = px
+	d.size[px] += d.size[py]
+	d.count--
+	return true
+}
+
+// Same reports whether x and y are in the same set
+func (d *DSU) Same(x, y int) bool {
+	return d.Find(x) == d.Find(y)
+}
+
+// Size returns the number of elements in x's set
+func (d *DSU) Size(x int) int {
+	return d.size[d.Find(x)]
+}
+
+// Count returns the number of sets
+func (d *DSU) Count() int {
+	return d.count
+}
+
+// Components returns the members of every set, each in increasing order.
+// Sets are ordered by their smallest member.
+func (d *DSU) Components() [][]int {
+	return components(len(d.parent), d.Find)
+}
+
+func components(n int, find func(int) int) [][]int {
+	index := make(map[int]int)
+	var comps [][]int
+	for x := range n {
+		root := find(x)
+		i, ok := index[root]
+		if !ok {
+			i = len(comps)
+			index[root] = i
+			comps = append(comps, nil)
+		}
+		comps[i] = append(comps[i], x)
+	}
+	return comps
+}
+
+// Rollback is a union-find whose unions can be undone in LIFO order, as
+// needed for offline dynamic connectivity. It uses union by size without
+// path compression, so Find is O(log n).
+type Rollback struct {
+	parent  []int
+	size    []int
+	count   int
+	history []int // root attached by each successful union, -1 for no-ops
+}
+
+// NewRollback returns n singleton sets
+func NewRollback(n int) *Rollback {
+	r := &Rollback{parent: make([]int, n), size: make([]int, n), count: n}
+	for i := range n {
+		r.parent[i] = i
+		r.size[i] = 1
+	}
+	return r
+}
+
+// Len returns the number of elements
+func (r *Rollback) Len() int {
+	return len(r.parent)
+}
+
+// Find returns the representative of x's set
+func (r *Rollback) Find(x int) int {
+	for r.parent[x] != x {
+		x = r.parent[x]
+	}
+	return x
+}
+
+// Union merges the sets of x and y and reports whether they were separate.
+// Every call, merging or not, is one step for Undo.
+func (r *Rollback) Union(x, y int) bool {
+	px, py := r.Find(x), r.Find(y)
+	if px == py {
+		r.history = append(r.history, -1)
+		return false
+	}
+	if r.size[px] < r.size[py] {
+		px, py = py, px
+	}
+	r.parent[py] = px
+	r.size[px] += r.size[py]
+	r.count--
+	r.history = append(r.history, py)
+	return true
+}
+
+// Same reports whether x and y are in the same set
+func (r *Rollback) Same(x, y int) bool {
+	return r.Find(x) == r.Find(y)
+}
+
+// Size returns the number of elements in x's set
+func (r *Rollback) Size(x int) int {
+	return r.size[r.Find(x)]
+}
+
+// Count returns the number of sets
+func (r *Rollback) Count() int {
+	return r.count
+}
+
+// Components returns the members of every set like DSU.Components
+func (r *Rollback) Components() [][]int {
+	return components(len(r.parent), r.Find)
+}
+
+// Snapshot returns a marker for RollbackTo
+func (r *Rollback) Snapshot() int {
+	return len(r.history)
+}
+
+// Undo reverts the most recent Union and reports whether there was one
+func (r *Rollback) Undo() bool {
+	n := len(r.history)
+	if n == 0 {
+		return false
+	}
+	child := r.history[n-1]
+	r.history = r.history[:n-1]
+	if child >= 0 {
+		root := r.parent[child]
+		r.size[root] -= r.size[child]
+		r.parent[child] = child
+		r.count++
+	}
+	return true
+}
+
+// RollbackTo undoes every Union made since the snapshot was taken
+func (r *Rollback) RollbackTo(snapshot int) {
+	for len(r.history) > snapshot {
+		r.Undo()
+	}
+}
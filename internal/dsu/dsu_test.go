@@ -0,0 +1,157 @@
+package dsu
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// unionFind is the interface both implementations share, for table tests
+type unionFind interface {
+	Union(x, y int) bool
+	Same(x, y int) bool
+	Size(x int) int
+	Count() int
+	Components() [][]int
+}
+
+func TestUnion(t *testing.T) {
+	for name, d := range map[string]unionFind{"DSU": New(6), "Rollback": NewRollback(6)} {
+		t.Run(name, func(t *testing.T) {
+			if !d.Union(0, 1) || !d.Union(4, 1) || !d.Union(2, 5) {
+				t.Fatal("Union of separate sets returned false")
+			}
+			if d.Union(0, 4) {
+				t.Error("Union(0, 4) merged sets that were already joined")
+			}
+			if !d.Same(0, 4) || d.Same(0, 2) {
+				t.Error("Same gives wrong membership")
+			}
+			if d.Size(4) != 3 || d.Size(5) != 2 || d.Size(3) != 1 {
+				t.Errorf("sizes = %d, %d, %d; want 3, 2, 1", d.Size(4), d.Size(5), d.Size(3))
+			}
+			if d.Count() != 3 {
+				t.Errorf("Count() = %d, want 3", d.Count())
+			}
+			want := [][]int{{0, 1, 4}, {2, 5}, {3}}
+			if got := d.Components(); !slices.EqualFunc(got, want, slices.Equal) {
+				t.Errorf("Components() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFindLongChain(t *testing.T) {
+	const n = 1 << 16
+	d := New(n)
+	for i := 1; i < n; i++ {
+		d.Union(i, i-1)
+	}
+	if d.Count() != 1 || d.Size(n-1) != n {
+		t.Errorf("Count() = %d, Size = %d; want 1, %d", d.Count(), d.Size(n-1), n)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	r := NewRollback(5)
+	r.Union(0, 1)
+	snap := r.Snapshot()
+	r.Union(1, 2)
+	r.Union(0, 2) // no-op, still one undo step
+	r.Union(3, 4)
+	if r.Count() != 2 {
+		t.Fatalf("Count() = %d, want 2", r.Count())
+	}
+	if !r.Undo() || !r.Same(1, 2) || r.Same(3, 4) {
+		t.Error("Undo did not revert only the last union")
+	}
+	r.RollbackTo(snap)
+	if !r.Same(0, 1) || r.Same(1, 2) || r.Count() != 4 || r.Size(0) != 2 {
+		t.Errorf("after RollbackTo: Count() = %d, Size(0) = %d; want 4, 2", r.Count(), r.Size(0))
+	}
+	r.RollbackTo(0)
+	if r.Undo() {
+		t.Error("Undo with empty history returned true")
+	}
+	if r.Count() != 5 {
+		t.Errorf("Count() = %d, want 5", r.Count())
+	}
+}
+
+// TestOfflineConnectivity answers "how many components" after every edge
+// insertion or deletion, using a segment tree over time with Rollback, and
+// checks each answer against rebuilding a DSU from scratch.
+func TestOfflineConnectivity(t *testing.T) {
+	const n, steps = 12, 300
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	type edge struct{ a, b int }
+	alive := make(map[edge]int) // edge -> step it was added
+	type span struct {
+		e          edge
+		start, end int // alive during steps [start, end)
+	}
+	var spans []span
+	want := make([]int, steps)
+	for step := range steps {
+		e := edge{rng.IntN(n), rng.IntN(n)}
+		if e.a > e.b {
+			e.a, e.b = e.b, e.a
+		}
+		if start, ok := alive[e]; ok {
+			spans = append(spans, span{e, start, step})
+			delete(alive, e)
+		} else {
+			alive[e] = step
+		}
+		d := New(n)
+		for e := range alive {
+			d.Union(e.a, e.b)
+		}
+		want[step] = d.Count()
+	}
+	for e, start := range alive {
+		spans = append(spans, span{e, start, steps})
+	}
+
+	tree := make([][]edge, 4*steps)
+	var insert func(node, lo, hi int, s span)
+	insert = func(node, lo, hi int, s span) {
+		if s.end <= lo || hi <= s.start {
+			return
+		}
+		if s.start <= lo && hi <= s.end {
+			tree[node] = append(tree[node], s.e)
+			return
+		}
+		mid := (lo + hi) / 2
+		insert(2*node, lo, mid, s)
+		insert(2*node+1, mid, hi, s)
+	}
+	for _, s := range spans {
+		insert(1, 0, steps, s)
+	}
+
+	r := NewRollback(n)
+	got := make([]int, steps)
+	var walk func(node, lo, hi int)
+	walk = func(node, lo, hi int) {
+		snap := r.Snapshot()
+		for _, e := range tree[node] {
+			r.Union(e.a, e.b)
+		}
+		if hi-lo == 1 {
+			got[lo] = r.Count()
+		} else {
+			mid := (lo + hi) / 2
+			walk(2*node, lo, mid)
+			walk(2*node+1, mid, hi)
+		}
+		r.RollbackTo(snap)
+	}
+	walk(1, 0, steps)
+
+	if !slices.Equal(got, want) {
+		t.Errorf("offline component counts differ from rebuilding:\n got %v\nwant %v", got, want)
+	}
+}
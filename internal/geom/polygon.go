@@ -0,0 +1,272 @@
+// Package geom provides rectilinear polygons on the integer lattice.
+package geom
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Point is a lattice point
+type Point struct {
+	X, Y int
+}
+
+// Location classifies a point relative to a polygon
+type Location int
+
+const (
+	Outside Location = iota
+	Boundary
+	Inside
+)
+
+func (l Location) String() string {
+	switch l {
+	case Outside:
+		return "outside"
+	case Boundary:
+		return "boundary"
+	case Inside:
+		return "inside"
+	}
+	return fmt.Sprintf("Location(%d)", int(l))
+}
+
+// segment is an edge with its endpoints ordered: x1 <= x2 and y1 <= y2
+type segment struct {
+	x1, y1, x2, y2 int
+	horizontal     bool
+}
+
+// Polygon is a simple polygon whose edges are all horizontal or vertical
+type Polygon struct {
+	vertices []Point
+	segments []segment
+
+	once  sync.Once
+	index *rectIndex
+}
+
+// NewPolygon returns the polygon through vertices in order, closing back to
+// the first. Consecutive vertices must differ in exactly one coordinate.
+// The polygon is assumed not to cross itself.
+func NewPolygon(vertices []Point) (*Polygon, error) {
+	if len(vertices) < 4 {
+		return nil, fmt.Errorf("polygon needs at least 4 vertices, got %d", len(vertices))
+	}
+	p := &Polygon{vertices: slices.Clone(vertices), segments: make([]segment, len(vertices))}
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+		if a == b {
+			return nil, fmt.Errorf("vertex %d repeats %v", i+1, a)
+		}
+		if a.X != b.X && a.Y != b.Y {
+			return nil, fmt.Errorf("edge %v-%v is not axis-aligned", a, b)
+		}
+		p.segments[i] = segment{
+			x1:         min(a.X, b.X),
+			y1:         min(a.Y, b.Y),
+			x2:         max(a.X, b.X),
+			y2:         max(a.Y, b.Y),
+			horizontal: a.Y == b.Y,
+		}
+	}
+	return p, nil
+}
+
+// Vertices returns the corners in order; the slice must not be modified
+func (p *Polygon) Vertices() []Point {
+	return p.vertices
+}
+
+// Locate reports whether pt is inside, on the boundary of, or outside p
+func (p *Polygon) Locate(pt Point) Location {
+	crossings := 0
+	for _, s := range p.segments {
+		if s.x1 <= pt.X && pt.X <= s.x2 && s.y1 <= pt.Y && pt.Y <= s.y2 {
+			return Boundary
+		}
+		// Cast a ray from pt towards y = -inf. Only horizontal edges can
+		// cross it; counting each one half-open in x makes a ray through a
+		// vertex count once.
+		if s.horizontal && s.y1 < pt.Y && s.x1 <= pt.X && pt.X < s.x2 {
+			crossings++
+		}
+	}
+	if crossings%2 == 1 {
+		return Inside
+	}
+	return Outside
+}
+
+// Area returns the enclosed area by the shoelace formula
+func (p *Polygon) Area() int {
+	sum := 0
+	for i, a := range p.vertices {
+		b := p.vertices[(i+1)%len(p.vertices)]
+		sum += a.X*b.Y - b.X*a.Y
+	}
+	return abs(sum) / 2
+}
+
+// BoundaryPoints returns the number of lattice points on the edges
+func (p *Polygon) BoundaryPoints() int {
+	n := 0
+	for _, s := range p.segments {
+		n += s.x2 - s.x1 + s.y2 - s.y1
+	}
+	return n
+}
+
+// InteriorPoints returns the number of lattice points strictly inside,
+// using Pick's theorem: A = I + B/2 - 1
+func (p *Polygon) InteriorPoints() int {
+	return p.Area() - p.BoundaryPoints()/2 + 1
+}
+
+// LatticePoints returns the number of lattice points inside or on p, which
+// is the number of unit tiles covered when vertices are tile centres
+func (p *Polygon) LatticePoints() int {
+	return p.InteriorPoints() + p.BoundaryPoints()
+}
+
+// ContainsRect reports whether every lattice point of the closed
+// axis-aligned rectangle with opposite corners a and b lies inside or on p.
+// The first call builds a compressed index in O(n²); later calls take
+// O(log n).
+func (p *Polygon) ContainsRect(a, b Point) bool {
+	p.once.Do(func() { p.index = newRectIndex(p) })
+	return p.index.contains(a, b)
+}
+
+// Compress returns the distinct values in increasing order. The position
+// of a value, found with slices.BinarySearch, is its compressed coordinate.
+func Compress(values []int) []int {
+	out := slices.Clone(values)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// rectIndex answers rectangle queries on the compressed plane. With xs the
+// distinct vertex x values, column 2k is the line x = xs[k] and column
+// 2k+1 the strip of x values strictly between xs[k] and xs[k+1]; rows
+// likewise for y. No edge passes through the inside of any such element,
+// so all of its lattice points share one Location.
+type rectIndex struct {
+	xs, ys []int
+	w, h   int   // compressed columns and rows
+	out    []int // 2D prefix sums of elements with lattice points outside
+}
+
+func newRectIndex(p *Polygon) *rectIndex {
+	xs, ys := make([]int, len(p.vertices)), make([]int, len(p.vertices))
+	for i, v := range p.vertices {
+		xs[i], ys[i] = v.X, v.Y
+	}
+	xs, ys = Compress(xs), Compress(ys)
+	w, h := 2*len(xs)-1, 2*len(ys)-1
+	at := func(r, c int) int { return r*w + c }
+
+	// Mark the line elements covered by an edge
+	wall := make([]bool, w*h)
+	for _, s := range p.segments {
+		c1, c2 := 2*index(xs, s.x1), 2*index(xs, s.x2)
+		r1, r2 := 2*index(ys, s.y1), 2*index(ys, s.y2)
+		for r := r1; r <= r2; r++ {
+			for c := c1; c <= c2; c++ {
+				wall[at(r, c)] = true
+			}
+		}
+	}
+
+	// A strip cell is inside when an odd number of vertical edges lie to its
+	// left within its rows
+	loc := make([]Location, w*h)
+	for r := 1; r < h; r += 2 {
+		in := false
+		for c := 0; c < w; c++ {
+			if c%2 == 0 && wall[at(r, c)] {
+				in = !in
+			} else if c%2 == 1 && in {
+				loc[at(r, c)] = Inside
+			}
+		}
+	}
+	// Line and point elements off the boundary share the Location of the
+	// strip cells around them
+	for r := range h {
+		for c := range w {
+			if r%2 == 1 && c%2 == 1 {
+				continue
+			}
+			if wall[at(r, c)] {
+				loc[at(r, c)] = Boundary
+				continue
+			}
+			rr, cc := r|1, c|1 // a neighbouring strip cell
+			if rr >= h {
+				rr = r - 1
+			}
+			if cc >= w {
+				cc = c - 1
+			}
+			if rr >= 0 && cc >= 0 {
+				loc[at(r, c)] = loc[at(rr, cc)]
+			}
+		}
+	}
+
+	// Strips between consecutive values hold no lattice points
+	empty := func(vals []int, i int) bool {
+		return i%2 == 1 && vals[i/2+1] == vals[i/2]+1
+	}
+	out := make([]int, (w+1)*(h+1))
+	for r := range h {
+		for c := range w {
+			v := 0
+			if loc[at(r, c)] == Outside && !empty(ys, r) && !empty(xs, c) {
+				v = 1
+			}
+			out[(r+1)*(w+1)+c+1] = v + out[r*(w+1)+c+1] + out[(r+1)*(w+1)+c] - out[r*(w+1)+c]
+		}
+	}
+	return &rectIndex{xs: xs, ys: ys, w: w, h: h, out: out}
+}
+
+// index returns the position of v, which must be present, in sorted vals
+func index(vals []int, v int) int {
+	i, _ := slices.BinarySearch(vals, v)
+	return i
+}
+
+// compressed maps v to its compressed line or strip, or -1 when v is
+// beyond every vertex and so outside the polygon
+func compressed(vals []int, v int) int {
+	i, found := slices.BinarySearch(vals, v)
+	switch {
+	case found:
+		return 2 * i
+	case i == 0 || i == len(vals):
+		return -1
+	}
+	return 2*i - 1
+}
+
+func (ix *rectIndex) contains(a, b Point) bool {
+	c1, c2 := compressed(ix.xs, min(a.X, b.X)), compressed(ix.xs, max(a.X, b.X))
+	r1, r2 := compressed(ix.ys, min(a.Y, b.Y)), compressed(ix.ys, max(a.Y, b.Y))
+	if c1 < 0 || c2 < 0 || r1 < 0 || r2 < 0 {
+		return false
+	}
+	w := ix.w + 1
+	n := ix.out[(r2+1)*w+c2+1] - ix.out[r1*w+c2+1] - ix.out[(r2+1)*w+c1] + ix.out[r1*w+c1]
+	return n == 0
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
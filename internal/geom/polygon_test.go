@@ -0,0 +1,174 @@
+package geom
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// example is the red-tile loop from the day 9 puzzle
+var example = []Point{{7, 1}, {11, 1}, {11, 7}, {9, 7}, {9, 5}, {2, 5}, {2, 3}, {7, 3}}
+
+func mustPolygon(t *testing.T, vs []Point) *Polygon {
+	t.Helper()
+	p, err := NewPolygon(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestNewPolygonErrors(t *testing.T) {
+	tests := map[string][]Point{
+		"too few":  {{0, 0}, {1, 0}, {1, 1}},
+		"diagonal": {{0, 0}, {2, 0}, {2, 2}, {1, 3}},
+		"repeat":   {{0, 0}, {2, 0}, {2, 0}, {2, 2}, {0, 2}},
+		"closing":  {{0, 0}, {2, 0}, {2, 2}, {1, 2}},
+	}
+	for name, vs := range tests {
+		if _, err := NewPolygon(vs); err == nil {
+			t.Errorf("%s: NewPolygon(%v) succeeded", name, vs)
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	p := mustPolygon(t, example)
+	tests := []struct {
+		pt   Point
+		want Location
+	}{
+		{Point{8, 2}, Inside},
+		{Point{3, 4}, Inside},
+		{Point{10, 6}, Inside},
+		{Point{7, 1}, Boundary},
+		{Point{9, 1}, Boundary},
+		{Point{2, 4}, Boundary},
+		{Point{9, 6}, Boundary},
+		{Point{8, 6}, Outside},
+		{Point{1, 4}, Outside},
+		{Point{3, 2}, Outside},
+		// The ray from here passes through the vertices at x = 7
+		{Point{7, 6}, Outside},
+		{Point{7, 4}, Inside},
+	}
+	for _, tc := range tests {
+		if got := p.Locate(tc.pt); got != tc.want {
+			t.Errorf("Locate(%v) = %v, want %v", tc.pt, got, tc.want)
+		}
+	}
+}
+
+func TestCounts(t *testing.T) {
+	square := mustPolygon(t, []Point{{0, 0}, {4, 0}, {4, 3}, {0, 3}})
+	if square.Area() != 12 || square.BoundaryPoints() != 14 || square.InteriorPoints() != 6 || square.LatticePoints() != 20 {
+		t.Errorf("4x3 rectangle: area %d, boundary %d, interior %d, lattice %d; want 12, 14, 6, 20",
+			square.Area(), square.BoundaryPoints(), square.InteriorPoints(), square.LatticePoints())
+	}
+
+	// Orientation does not change the answers
+	rev := slices.Clone(example)
+	slices.Reverse(rev)
+	for _, vs := range [][]Point{example, rev} {
+		p := mustPolygon(t, vs)
+		lattice := 0
+		for x := range 13 {
+			for y := range 9 {
+				if p.Locate(Point{x, y}) != Outside {
+					lattice++
+				}
+			}
+		}
+		if p.Area() != 30 || p.LatticePoints() != lattice {
+			t.Errorf("Area() = %d, LatticePoints() = %d; want 30, %d", p.Area(), p.LatticePoints(), lattice)
+		}
+	}
+}
+
+func TestCompress(t *testing.T) {
+	if got := Compress([]int{7, 2, 11, 2, 7}); !slices.Equal(got, []int{2, 7, 11}) {
+		t.Errorf("Compress() = %v, want [2 7 11]", got)
+	}
+}
+
+// columnPolygon builds a random polygon out of adjacent columns, each
+// spanning [lo, hi) rows, with neighbouring columns overlapping so the
+// outline is simple
+func columnPolygon(rng *rand.Rand) []Point {
+	type column struct{ x1, x2, lo, hi int }
+	var cols []column
+	x := 0
+	for i := range 2 + rng.IntN(5) {
+		c := column{x1: x, x2: x + 1 + rng.IntN(3)}
+		for {
+			c.lo, c.hi = rng.IntN(6), 1+rng.IntN(8)
+			if c.lo >= c.hi {
+				continue
+			}
+			if i == 0 || (c.lo < cols[i-1].hi && cols[i-1].lo < c.hi) {
+				break
+			}
+		}
+		cols = append(cols, c)
+		x = c.x2
+	}
+
+	var vs []Point
+	add := func(p Point) {
+		if n := len(vs); n > 0 && vs[n-1] == p {
+			return
+		}
+		vs = append(vs, p)
+	}
+	for _, c := range cols {
+		add(Point{c.x1, c.hi})
+		add(Point{c.x2, c.hi})
+	}
+	for i := len(cols) - 1; i >= 0; i-- {
+		add(Point{cols[i].x2, cols[i].lo})
+		add(Point{cols[i].x1, cols[i].lo})
+	}
+	// Drop vertices in the middle of a straight edge
+	var out []Point
+	for i, v := range vs {
+		prev, next := vs[(i+len(vs)-1)%len(vs)], vs[(i+1)%len(vs)]
+		if (prev.X == v.X && v.X == next.X) || (prev.Y == v.Y && v.Y == next.Y) {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestContainsRect(t *testing.T) {
+	polygons := [][]Point{example}
+	rng := rand.New(rand.NewPCG(3, 5))
+	for range 200 {
+		polygons = append(polygons, columnPolygon(rng))
+	}
+	for _, vs := range polygons {
+		p := mustPolygon(t, vs)
+		const lo, hi = -1, 14
+		for x1 := lo; x1 < hi; x1++ {
+			for y1 := lo; y1 < 10; y1++ {
+				for x2 := x1; x2 < hi; x2++ {
+					for y2 := y1; y2 < 10; y2++ {
+						want := true
+						for x := x1; x <= x2 && want; x++ {
+							for y := y1; y <= y2; y++ {
+								if p.Locate(Point{x, y}) == Outside {
+									want = false
+									break
+								}
+							}
+						}
+						a, b := Point{x1, y2}, Point{x2, y1}
+						if got := p.ContainsRect(a, b); got != want {
+							t.Fatalf("polygon %v: ContainsRect(%v, %v) = %v, want %v", vs, a, b, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
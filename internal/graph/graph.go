@@ -0,0 +1,277 @@
+// Package graph provides a compact directed graph over named nodes.
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"aoc2025/internal/aoc"
+)
+
+// ErrCycle is returned when a topological order is asked of a cyclic graph
+var ErrCycle = errors.New("graph has a cycle")
+
+// Graph is an immutable directed graph. Node names are interned to the IDs
+// 0..Len()-1 and adjacency is kept in compressed sparse row form.
+type Graph struct {
+	names   []string
+	ids     map[string]int
+	offsets []int // edges of node v are edges[offsets[v]:offsets[v+1]]
+	edges   []int
+}
+
+// Builder collects nodes and edges for a Graph
+type Builder struct {
+	names    []string
+	ids      map[string]int
+	from, to []int
+}
+
+// NewBuilder returns an empty Builder
+func NewBuilder() *Builder {
+	return &Builder{ids: make(map[string]int)}
+}
+
+// Node returns the ID of name, adding the node if it is new
+func (b *Builder) Node(name string) int {
+	if id, ok := b.ids[name]; ok {
+		return id
+	}
+	id := len(b.names)
+	b.names = append(b.names, name)
+	b.ids[name] = id
+	return id
+}
+
+// Edge adds an edge between two node IDs returned by Node
+func (b *Builder) Edge(from, to int) {
+	b.from = append(b.from, from)
+	b.to = append(b.to, to)
+}
+
+// Build returns the graph. Each node's edges keep the order they were added in.
+func (b *Builder) Build() *Graph {
+	n := len(b.names)
+	offsets := make([]int, n+1)
+	for _, v := range b.from {
+		offsets[v+1]++
+	}
+	for v := range n {
+		offsets[v+1] += offsets[v]
+	}
+	edges := make([]int, len(b.to))
+	next := append([]int(nil), offsets[:n]...)
+	for i, v := range b.from {
+		edges[next[v]] = b.to[i]
+		next[v]++
+	}
+	return &Graph{names: b.names, ids: b.ids, offsets: offsets, edges: edges}
+}
+
+// Parse reads "name: a b c" adjacency lines into a graph. Target names
+// become nodes even when they have no line of their own.
+func Parse(day int, lines []string) (*Graph, error) {
+	b := NewBuilder()
+	listed := make(map[string]bool)
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		from, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, aoc.ParseErrorf(day, i+1, 0, "want \"name: targets\"")
+		}
+		if from == "" || strings.ContainsAny(from, " \t") {
+			return nil, aoc.ParseErrorf(day, i+1, 1, "invalid node name %q", from)
+		}
+		if listed[from] {
+			return nil, aoc.ParseErrorf(day, i+1, 1, "node %q listed twice", from)
+		}
+		listed[from] = true
+		v := b.Node(from)
+		for _, to := range strings.Fields(rest) {
+			b.Edge(v, b.Node(to))
+		}
+	}
+	return b.Build(), nil
+}
+
+// Len returns the number of nodes
+func (g *Graph) Len() int {
+	return len(g.names)
+}
+
+// Name returns the name of node v
+func (g *Graph) Name(v int) string {
+	return g.names[v]
+}
+
+// ID returns the node called name
+func (g *Graph) ID(name string) (int, bool) {
+	id, ok := g.ids[name]
+	return id, ok
+}
+
+// Out returns the targets of v's edges; the slice must not be modified
+func (g *Graph) Out(v int) []int {
+	return g.edges[g.offsets[v]:g.offsets[v+1]]
+}
+
+// Edges returns the number of edges
+func (g *Graph) Edges() int {
+	return len(g.edges)
+}
+
+// Reverse returns g with every edge flipped
+func (g *Graph) Reverse() *Graph {
+	b := &Builder{names: g.names, ids: g.ids}
+	for v := range g.Len() {
+		for _, w := range g.Out(v) {
+			b.Edge(w, v)
+		}
+	}
+	return b.Build()
+}
+
+// Reachable marks every node reachable from the given roots, roots included
+func (g *Graph) Reachable(roots ...int) []bool {
+	seen := make([]bool, g.Len())
+	stack := make([]int, 0, len(roots))
+	for _, r := range roots {
+		if !seen[r] {
+			seen[r] = true
+			stack = append(stack, r)
+		}
+	}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, w := range g.Out(v) {
+			if !seen[w] {
+				seen[w] = true
+				stack = append(stack, w)
+			}
+		}
+	}
+	return seen
+}
+
+// TopoSort orders the nodes reachable from roots, or every node when no
+// roots are given, so that each edge points forwards. A cycle among those
+// nodes is reported as an error wrapping ErrCycle.
+func (g *Graph) TopoSort(roots ...int) ([]int, error) {
+	if len(roots) == 0 {
+		roots = make([]int, g.Len())
+		for v := range roots {
+			roots[v] = v
+		}
+	}
+
+	const (
+		unseen = iota
+		active
+		done
+	)
+	state := make([]uint8, g.Len())
+	post := make([]int, 0, g.Len())
+
+	// Iterative DFS; each frame is a node and the index of its next edge
+	type frame struct{ v, next int }
+	var stack []frame
+	for _, r := range roots {
+		if state[r] != unseen {
+			continue
+		}
+		state[r] = active
+		stack = append(stack, frame{r, 0})
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			out := g.Out(top.v)
+			if top.next == len(out) {
+				state[top.v] = done
+				post = append(post, top.v)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			w := out[top.next]
+			top.next++
+			switch state[w] {
+			case active:
+				return nil, fmt.Errorf("%w through %q", ErrCycle, g.names[w])
+			case unseen:
+				state[w] = active
+				stack = append(stack, frame{w, 0})
+			}
+		}
+	}
+
+	for i, j := 0, len(post)-1; i < j; i, j = i+1, j-1 {
+		post[i], post[j] = post[j], post[i]
+	}
+	return post, nil
+}
+
+// SCC returns the strongly connected components using Tarjan's algorithm.
+// Components come out in reverse topological order of the condensation:
+// no component has an edge into a later one.
+func (g *Graph) SCC() [][]int {
+	n := g.Len()
+	index := make([]int, n) // 1-based discovery order, 0 when unvisited
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	var comps [][]int
+	var stack []int
+	counter := 0
+
+	type frame struct{ v, next int }
+	var calls []frame
+	for root := range n {
+		if index[root] != 0 {
+			continue
+		}
+		calls = append(calls, frame{root, 0})
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+			v := top.v
+			if top.next == 0 {
+				counter++
+				index[v], low[v] = counter, counter
+				stack = append(stack, v)
+				onStack[v] = true
+			}
+			out := g.Out(v)
+			if top.next < len(out) {
+				w := out[top.next]
+				top.next++
+				if index[w] == 0 {
+					calls = append(calls, frame{w, 0})
+				} else if onStack[w] {
+					low[v] = min(low[v], index[w])
+				}
+				continue
+			}
+
+			// All edges of v are done: pop it and fold its low link into the caller
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				u := calls[len(calls)-1].v
+				low[u] = min(low[u], low[v])
+			}
+			if low[v] == index[v] {
+				var comp []int
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					comp = append(comp, w)
+					if w == v {
+						break
+					}
+				}
+				comps = append(comps, comp)
+			}
+		}
+	}
+	return comps
+}
@@ -0,0 +1,190 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	"aoc2025/internal/aoc"
+)
+
+func mustParse(t *testing.T, lines ...string) *Graph {
+	t.Helper()
+	g, err := Parse(0, lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// names maps node IDs back to their names
+func names(g *Graph, ids []int) []string {
+	out := make([]string, len(ids))
+	for i, v := range ids {
+		out[i] = g.Name(v)
+	}
+	return out
+}
+
+func id(t *testing.T, g *Graph, name string) int {
+	t.Helper()
+	v, ok := g.ID(name)
+	if !ok {
+		t.Fatalf("no node %q", name)
+	}
+	return v
+}
+
+func TestParse(t *testing.T) {
+	g := mustParse(t, "a: b c", "", "b: c", "d:")
+	if g.Len() != 4 || g.Edges() != 3 {
+		t.Fatalf("graph has %d nodes and %d edges, want 4 and 3", g.Len(), g.Edges())
+	}
+	if got := names(g, g.Out(id(t, g, "a"))); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("Out(a) = %v, want [b c]", got)
+	}
+	if got := g.Out(id(t, g, "c")); len(got) != 0 {
+		t.Errorf("Out(c) = %v, want none", got)
+	}
+	if _, ok := g.ID("e"); ok {
+		t.Error("ID(e) found a node")
+	}
+
+	tests := []struct {
+		lines     []string
+		line, col int
+	}{
+		{[]string{"a: b", "b c"}, 2, 0},
+		{[]string{": b"}, 1, 1},
+		{[]string{"a b: c"}, 1, 1},
+		{[]string{"a: b", "a: c"}, 2, 1},
+	}
+	for _, tc := range tests {
+		_, err := Parse(11, tc.lines)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Day != 11 || pe.Line != tc.line || pe.Column != tc.col {
+			t.Errorf("Parse(%q) error = %v, want ParseError at %d:%d", tc.lines, err, tc.line, tc.col)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	g := mustParse(t, "a: b c", "b: c").Reverse()
+	if got := names(g, g.Out(id(t, g, "c"))); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("reversed Out(c) = %v, want [a b]", got)
+	}
+	if got := g.Out(id(t, g, "a")); len(got) != 0 {
+		t.Errorf("reversed Out(a) = %v, want none", got)
+	}
+}
+
+func TestReachable(t *testing.T) {
+	g := mustParse(t, "a: b", "b: c", "d: a")
+	seen := g.Reachable(id(t, g, "b"))
+	for name, want := range map[string]bool{"a": false, "b": true, "c": true, "d": false} {
+		if seen[id(t, g, name)] != want {
+			t.Errorf("reachable from b: %s = %v, want %v", name, !want, want)
+		}
+	}
+}
+
+// checkOrder fails unless every edge between nodes in order points forwards
+func checkOrder(t *testing.T, g *Graph, order []int) {
+	t.Helper()
+	pos := make(map[int]int)
+	for i, v := range order {
+		pos[v] = i
+	}
+	for v := range pos {
+		for _, w := range g.Out(v) {
+			if pos[w] <= pos[v] {
+				t.Errorf("edge %s -> %s points backwards in %v", g.Name(v), g.Name(w), names(g, order))
+			}
+		}
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	g := mustParse(t, "e: d", "a: b c", "b: d", "c: d", "x: y")
+	order, err := g.TopoSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != g.Len() {
+		t.Fatalf("order has %d nodes, want %d", len(order), g.Len())
+	}
+	checkOrder(t, g, order)
+
+	order, err = g.TopoSort(id(t, g, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(g, order); got[0] != "a" || got[3] != "d" || len(got) != 4 {
+		t.Errorf("TopoSort(a) = %v, want a first and d last", got)
+	}
+
+	cyclic := mustParse(t, "a: b", "b: c", "c: a", "d: a")
+	if _, err := cyclic.TopoSort(); !errors.Is(err, ErrCycle) {
+		t.Errorf("TopoSort(cyclic) error = %v, want ErrCycle", err)
+	}
+	if _, err := cyclic.TopoSort(id(t, cyclic, "d")); !errors.Is(err, ErrCycle) {
+		t.Errorf("TopoSort(d) error = %v, want ErrCycle", err)
+	}
+}
+
+func TestSCC(t *testing.T) {
+	g := mustParse(t,
+		"a: b",
+		"b: c e",
+		"c: a d",
+		"d: d",
+		"e: f",
+		"f: g",
+		"g: e",
+	)
+	comps := g.SCC()
+	var got [][]string
+	compOf := make(map[int]int)
+	for i, c := range comps {
+		got = append(got, names(g, c))
+		slices.Sort(got[i])
+		for _, v := range c {
+			compOf[v] = i
+		}
+	}
+	want := map[string]bool{"[a b c]": true, "[d]": true, "[e f g]": true}
+	if len(got) != len(want) {
+		t.Fatalf("SCC() = %v, want 3 components", got)
+	}
+	for _, c := range got {
+		if !want[fmt.Sprint(c)] {
+			t.Errorf("unexpected component %v", c)
+		}
+	}
+	// Edges between components only point to earlier ones
+	for v := range g.Len() {
+		for _, w := range g.Out(v) {
+			if compOf[w] > compOf[v] {
+				t.Errorf("edge %s -> %s points to a later component", g.Name(v), g.Name(w))
+			}
+		}
+	}
+}
+
+func TestSCCDeepChain(t *testing.T) {
+	// Long enough that a recursive implementation would be a poor fit
+	b := NewBuilder()
+	const n = 100000
+	for i := range n - 1 {
+		b.Edge(b.Node(strconv.Itoa(i)), b.Node(strconv.Itoa(i+1)))
+	}
+	g := b.Build()
+	if got := len(g.SCC()); got != n {
+		t.Errorf("SCC() found %d components, want %d", got, n)
+	}
+	if _, err := g.TopoSort(); err != nil {
+		t.Error(err)
+	}
+}
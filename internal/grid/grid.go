@@ -0,0 +1,221 @@
+// Package grid provides a dense 2D grid for puzzles drawn as text.
+package grid
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"unicode/utf8"
+
+	"aoc2025/internal/aoc"
+)
+
+// Point is a cell position; rows grow downwards
+type Point struct {
+	Row, Col int
+}
+
+// Add returns p moved by d
+func (p Point) Add(d Point) Point {
+	return Point{p.Row + d.Row, p.Col + d.Col}
+}
+
+// Dirs4 are the orthogonal directions: N, E, S, W
+var Dirs4 = [4]Point{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+
+// Dirs8 are all eight directions: N, NE, E, SE, S, SW, W, NW
+var Dirs8 = [8]Point{
+	{-1, 0}, {-1, 1}, {0, 1}, {1, 1},
+	{1, 0}, {1, -1}, {0, -1}, {-1, -1},
+}
+
+// Grid is a Width x Height grid of cells stored row-major in one slice
+type Grid[T any] struct {
+	Width, Height int
+	cells         []T
+	lines         []int // input line of each row, set by Parse
+}
+
+// New returns a grid of zero cells
+func New[T any](width, height int) *Grid[T] {
+	if width < 0 || height < 0 {
+		panic(fmt.Sprintf("grid: negative size %dx%d", width, height))
+	}
+	return &Grid[T]{Width: width, Height: height, cells: make([]T, width*height)}
+}
+
+// Parse builds a grid from puzzle lines, converting every rune with f.
+// Blank lines are skipped, so Line maps rows back to the input; rows of
+// different widths are a ParseError for day.
+func Parse[T any](day int, lines []string, f func(rune) T) (*Grid[T], error) {
+	g := &Grid[T]{}
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		n := utf8.RuneCountInString(line)
+		if g.Height == 0 {
+			g.Width = n
+		} else if n != g.Width {
+			return nil, aoc.ParseErrorf(day, i+1, 0, "row has %d cells, want %d", n, g.Width)
+		}
+		for _, r := range line {
+			g.cells = append(g.cells, f(r))
+		}
+		g.lines = append(g.lines, i+1)
+		g.Height++
+	}
+	return g, nil
+}
+
+// Line returns the 1-based input line that row was parsed from; for grids
+// not built by Parse it is row+1
+func (g *Grid[T]) Line(row int) int {
+	if row >= 0 && row < len(g.lines) {
+		return g.lines[row]
+	}
+	return row + 1
+}
+
+// In reports whether p lies inside the grid
+func (g *Grid[T]) In(p Point) bool {
+	return p.Row >= 0 && p.Row < g.Height && p.Col >= 0 && p.Col < g.Width
+}
+
+func (g *Grid[T]) index(p Point) int {
+	if !g.In(p) {
+		panic(fmt.Sprintf("grid: %v outside %dx%d grid", p, g.Width, g.Height))
+	}
+	return p.Row*g.Width + p.Col
+}
+
+// At returns the cell at p, panicking if p is outside the grid
+func (g *Grid[T]) At(p Point) T {
+	return g.cells[g.index(p)]
+}
+
+// Get returns the cell at p and whether p is inside the grid
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row*g.Width+p.Col], true
+}
+
+// Set stores v at p, panicking if p is outside the grid
+func (g *Grid[T]) Set(p Point, v T) {
+	g.cells[g.index(p)] = v
+}
+
+// Clone returns an independent copy of g
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{Width: g.Width, Height: g.Height, cells: append([]T(nil), g.cells...), lines: g.lines}
+}
+
+// All yields every cell in row-major order
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Point{i / g.Width, i % g.Width}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbours4 yields the orthogonal neighbours of p that lie inside the grid
+func (g *Grid[T]) Neighbours4(p Point) iter.Seq[Point] {
+	return g.neighbours(p, Dirs4[:])
+}
+
+// Neighbours8 yields all neighbours of p that lie inside the grid
+func (g *Grid[T]) Neighbours8(p Point) iter.Seq[Point] {
+	return g.neighbours(p, Dirs8[:])
+}
+
+func (g *Grid[T]) neighbours(p Point, dirs []Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, d := range dirs {
+			if q := p.Add(d); g.In(q) && !yield(q) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns the first cell, in row-major order, equal to r
+func Find[T ~byte | ~rune](g *Grid[T], r rune) (Point, bool) {
+	for p, v := range g.All() {
+		if rune(v) == r {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAll returns every cell equal to r in row-major order
+func FindAll[T ~byte | ~rune](g *Grid[T], r rune) []Point {
+	var ps []Point
+	for p, v := range g.All() {
+		if rune(v) == r {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// remap builds a width x height grid whose cell p is g's cell src(p)
+func (g *Grid[T]) remap(width, height int, src func(Point) Point) *Grid[T] {
+	out := New[T](width, height)
+	for i := range out.cells {
+		out.cells[i] = g.At(src(Point{i / width, i % width}))
+	}
+	return out
+}
+
+// Transpose mirrors g along its main diagonal
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point { return Point{p.Col, p.Row} })
+}
+
+// Rotate turns g a quarter turn clockwise
+func (g *Grid[T]) Rotate() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point { return Point{g.Height - 1 - p.Col, p.Row} })
+}
+
+// FlipH mirrors g left to right
+func (g *Grid[T]) FlipH() *Grid[T] {
+	return g.remap(g.Width, g.Height, func(p Point) Point { return Point{p.Row, g.Width - 1 - p.Col} })
+}
+
+// FlipV mirrors g top to bottom
+func (g *Grid[T]) FlipV() *Grid[T] {
+	return g.remap(g.Width, g.Height, func(p Point) Point { return Point{g.Height - 1 - p.Row, p.Col} })
+}
+
+// String renders one line per row. Bytes and runes print as characters,
+// booleans as '#' and '.', anything else with fmt.
+func (g *Grid[T]) String() string {
+	var b strings.Builder
+	for i, v := range g.cells {
+		if i > 0 && i%g.Width == 0 {
+			b.WriteByte('\n')
+		}
+		switch c := any(v).(type) {
+		case byte:
+			b.WriteByte(c)
+		case rune:
+			b.WriteRune(c)
+		case bool:
+			if c {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		default:
+			fmt.Fprint(&b, c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package grid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"aoc2025/internal/aoc"
+)
+
+func parseBytes(t *testing.T, lines ...string) *Grid[byte] {
+	t.Helper()
+	g, err := Parse(0, lines, func(r rune) byte { return byte(r) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g := parseBytes(t, "ab.", "c#d", "")
+	if g.Width != 3 || g.Height != 2 {
+		t.Fatalf("size = %dx%d, want 3x2", g.Width, g.Height)
+	}
+	if got := g.At(Point{1, 2}); got != 'd' {
+		t.Errorf("At(1,2) = %q, want 'd'", got)
+	}
+	if got := g.String(); got != "ab.\nc#d" {
+		t.Errorf("String() = %q", got)
+	}
+
+	g = parseBytes(t, "", "ab", "", "cd")
+	for row, want := range []int{2, 4} {
+		if got := g.Line(row); got != want {
+			t.Errorf("Line(%d) = %d, want %d", row, got, want)
+		}
+	}
+	if got := New[int](2, 2).Line(1); got != 2 {
+		t.Errorf("Line(1) on a new grid = %d, want 2", got)
+	}
+
+	_, err := Parse(4, []string{"...", "..", "..."}, func(r rune) rune { return r })
+	var pe *aoc.ParseError
+	if !errors.As(err, &pe) || pe.Day != 4 || pe.Line != 2 {
+		t.Errorf("Parse(ragged) error = %v, want ParseError on day 4 line 2", err)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	g := New[int](3, 2)
+	g.Set(Point{1, 0}, 7)
+	if v, ok := g.Get(Point{1, 0}); !ok || v != 7 {
+		t.Errorf("Get(1,0) = %d, %v; want 7, true", v, ok)
+	}
+	// (0,3) would alias (1,0) in the backing slice
+	for _, p := range []Point{{0, 3}, {-1, 0}, {2, 0}, {0, -1}} {
+		if _, ok := g.Get(p); ok {
+			t.Errorf("Get(%v) reported inside", p)
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("At(%v) did not panic", p)
+				}
+			}()
+			g.At(p)
+		}()
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	g := New[bool](3, 3)
+	tests := []struct {
+		p      Point
+		n4, n8 int
+	}{
+		{Point{0, 0}, 2, 3},
+		{Point{0, 1}, 3, 5},
+		{Point{1, 1}, 4, 8},
+	}
+	for _, tc := range tests {
+		if got := len(slices.Collect(g.Neighbours4(tc.p))); got != tc.n4 {
+			t.Errorf("Neighbours4(%v) yielded %d points, want %d", tc.p, got, tc.n4)
+		}
+		if got := len(slices.Collect(g.Neighbours8(tc.p))); got != tc.n8 {
+			t.Errorf("Neighbours8(%v) yielded %d points, want %d", tc.p, got, tc.n8)
+		}
+	}
+	want := []Point{{0, 1}, {1, 2}, {2, 1}, {1, 0}}
+	if got := slices.Collect(g.Neighbours4(Point{1, 1})); !slices.Equal(got, want) {
+		t.Errorf("Neighbours4(1,1) = %v, want %v", got, want)
+	}
+}
+
+func TestFind(t *testing.T) {
+	g := parseBytes(t, ".S.", "..S")
+	if p, ok := Find(g, 'S'); !ok || p != (Point{0, 1}) {
+		t.Errorf("Find(S) = %v, %v; want (0,1), true", p, ok)
+	}
+	if _, ok := Find(g, 'X'); ok {
+		t.Error("Find(X) found a cell")
+	}
+	if got, want := FindAll(g, 'S'), []Point{{0, 1}, {1, 2}}; !slices.Equal(got, want) {
+		t.Errorf("FindAll(S) = %v, want %v", got, want)
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	g := parseBytes(t, "abc", "def")
+	tests := []struct {
+		name string
+		got  *Grid[byte]
+		want string
+	}{
+		{"transpose", g.Transpose(), "ad\nbe\ncf"},
+		{"rotate", g.Rotate(), "da\neb\nfc"},
+		{"rotate twice", g.Rotate().Rotate(), "fed\ncba"},
+		{"flipH", g.FlipH(), "cba\nfed"},
+		{"flipV", g.FlipV(), "def\nabc"},
+	}
+	for _, tc := range tests {
+		if got := tc.got.String(); got != tc.want {
+			t.Errorf("%s = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+	if got := g.String(); got != "abc\ndef" {
+		t.Errorf("transforms modified the original: %q", got)
+	}
+}
+
+func TestString(t *testing.T) {
+	g := New[bool](2, 2)
+	g.Set(Point{0, 1}, true)
+	if got := g.String(); got != ".#\n.." {
+		t.Errorf("String() = %q", got)
+	}
+	n := New[int](2, 1)
+	n.Set(Point{0, 0}, 4)
+	if got := n.String(); got != "40" {
+		t.Errorf("String() = %q", got)
+	}
+}
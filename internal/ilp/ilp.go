@@ -0,0 +1,198 @@
+// Package ilp solves small integer linear programs exactly by branch and
+// bound over a rational simplex LP relaxation.
+package ilp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrInfeasible means no non-negative integer point satisfies the constraints
+	ErrInfeasible = errors.New("ilp: no integer solution")
+	// ErrUnbounded means the LP relaxation, and so possibly the problem, has no minimum
+	ErrUnbounded = errors.New("ilp: objective is unbounded")
+)
+
+// Problem is: minimise C·x subject to A x = B and x >= 0, x integer
+type Problem struct {
+	A [][]int
+	B []int
+	C []int
+}
+
+// Solution is an optimal point with the evidence for its optimality
+type Solution struct {
+	X     []int
+	Value int
+
+	// RootBound is the optimum of the LP relaxation, a lower bound on Value
+	RootBound *big.Rat
+	// Nodes counts the LP relaxations solved. Every node was either
+	// infeasible, integral, or had a relaxation bound no better than
+	// Value, which proves that no better integer point exists.
+	Nodes int
+}
+
+// bound restricts one variable to lo <= x <= hi; hi < 0 means no upper limit
+type bound struct {
+	lo, hi int
+}
+
+// Minimize solves p by depth-first branch and bound. Each node solves the
+// LP relaxation under its variable bounds, is pruned when the relaxation is
+// infeasible or its objective rounded up cannot beat the best integer point
+// found, and otherwise branches on the most fractional variable.
+func Minimize(p Problem) (Solution, error) {
+	n := len(p.C)
+	if len(p.A) != len(p.B) {
+		return Solution{}, fmt.Errorf("ilp: %d constraint rows but %d right-hand sides", len(p.A), len(p.B))
+	}
+	for i, row := range p.A {
+		if len(row) != n {
+			return Solution{}, fmt.Errorf("ilp: constraint row %d has %d coefficients, want %d", i, len(row), n)
+		}
+	}
+
+	var sol Solution
+	found := false
+	root := make([]bound, n)
+	for j := range root {
+		root[j].hi = -1
+	}
+	stack := [][]bound{root}
+	for len(stack) > 0 {
+		bounds := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		status, x, value := relax(p, bounds)
+		sol.Nodes++
+		switch status {
+		case lpInfeasible:
+			continue
+		case lpUnbounded:
+			return Solution{}, ErrUnbounded
+		}
+		if sol.Nodes == 1 {
+			sol.RootBound = value
+		}
+		// With an integer objective no point in this subtree beats ceil(value)
+		if found && ceil(value) >= sol.Value {
+			continue
+		}
+
+		branch, frac := -1, new(big.Rat)
+		for j := range x {
+			if x[j].IsInt() {
+				continue
+			}
+			if f := distanceToInt(&x[j]); branch < 0 || f.Cmp(frac) > 0 {
+				branch, frac = j, f
+			}
+		}
+		if branch < 0 {
+			sol.X = make([]int, n)
+			for j := range x {
+				sol.X[j] = int(x[j].Num().Int64())
+			}
+			sol.Value = int(value.Num().Int64())
+			found = true
+			continue
+		}
+
+		// Depth first: explore x <= floor before x >= floor+1
+		fl := floor(&x[branch])
+		up := append([]bound(nil), bounds...)
+		up[branch].lo = fl + 1
+		down := append([]bound(nil), bounds...)
+		down[branch].hi = fl
+		stack = append(stack, up, down)
+	}
+	if !found {
+		return Solution{}, ErrInfeasible
+	}
+	return sol, nil
+}
+
+// relax solves the LP relaxation of p under bounds. Lower bounds are
+// substituted away (x = lo + x'); upper bounds become rows x' + s = hi - lo.
+func relax(p Problem, bounds []bound) (lpStatus, []big.Rat, *big.Rat) {
+	n := len(p.C)
+	var upper []int
+	for j, b := range bounds {
+		if b.hi >= 0 {
+			if b.hi < b.lo {
+				return lpInfeasible, nil, nil
+			}
+			upper = append(upper, j)
+		}
+	}
+	cols := n + len(upper)
+	rows := len(p.A) + len(upper)
+
+	a := make([][]big.Rat, rows)
+	b := make([]big.Rat, rows)
+	c := make([]big.Rat, cols)
+	for i, row := range p.A {
+		a[i] = make([]big.Rat, cols)
+		rhs := p.B[i]
+		for j, v := range row {
+			a[i][j].SetInt64(int64(v))
+			rhs -= v * bounds[j].lo
+		}
+		b[i].SetInt64(int64(rhs))
+	}
+	for k, j := range upper {
+		i := len(p.A) + k
+		a[i] = make([]big.Rat, cols)
+		a[i][j].SetInt64(1)
+		a[i][n+k].SetInt64(1)
+		b[i].SetInt64(int64(bounds[j].hi - bounds[j].lo))
+	}
+	offset := 0
+	for j, v := range p.C {
+		c[j].SetInt64(int64(v))
+		offset += v * bounds[j].lo
+	}
+
+	status, x, value := solveLP(a, b, c)
+	if status != lpOptimal {
+		return status, nil, nil
+	}
+	x = x[:n]
+	var lo big.Rat
+	for j := range x {
+		x[j].Add(&x[j], lo.SetInt64(int64(bounds[j].lo)))
+	}
+	value.Add(value, lo.SetInt64(int64(offset)))
+	return lpOptimal, x, value
+}
+
+// floor returns the largest integer <= r
+func floor(r *big.Rat) int {
+	q := new(big.Int)
+	m := new(big.Int)
+	q.DivMod(r.Num(), r.Denom(), m) // Euclidean division rounds towards -inf for positive divisors
+	return int(q.Int64())
+}
+
+// ceil returns the smallest integer >= r
+func ceil(r *big.Rat) int {
+	f := floor(r)
+	if r.IsInt() {
+		return f
+	}
+	return f + 1
+}
+
+// distanceToInt returns how far r is from the nearest integer
+func distanceToInt(r *big.Rat) *big.Rat {
+	f := new(big.Rat).SetInt64(int64(floor(r)))
+	below := new(big.Rat).Sub(r, f)
+	above := new(big.Rat).Sub(big.NewRat(1, 1), below)
+	if below.Cmp(above) < 0 {
+		return below
+	}
+	return above
+}
@@ -0,0 +1,176 @@
+package ilp
+
+import (
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// check fails unless sol satisfies p and has the given value
+func check(t *testing.T, p Problem, sol Solution, want int) {
+	t.Helper()
+	if sol.Value != want {
+		t.Fatalf("Value = %d, want %d (x = %v)", sol.Value, want, sol.X)
+	}
+	value := 0
+	for j, x := range sol.X {
+		if x < 0 {
+			t.Fatalf("x[%d] = %d is negative", j, x)
+		}
+		value += p.C[j] * x
+	}
+	if value != sol.Value {
+		t.Fatalf("C·x = %d, but Value = %d", value, sol.Value)
+	}
+	for i, row := range p.A {
+		lhs := 0
+		for j, a := range row {
+			lhs += a * sol.X[j]
+		}
+		if lhs != p.B[i] {
+			t.Fatalf("row %d: A x = %d, want %d", i, lhs, p.B[i])
+		}
+	}
+	if sol.RootBound == nil || sol.RootBound.Cmp(big.NewRat(int64(sol.Value), 1)) > 0 {
+		t.Fatalf("RootBound %v is not a lower bound on %d", sol.RootBound, sol.Value)
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	// The first machine of the day 10 example: buttons (3) (1,3) (2) (2,3)
+	// (0,2) (0,1) and joltages {3,5,4,7} need 10 presses
+	p := Problem{
+		A: [][]int{
+			{0, 0, 0, 0, 1, 1},
+			{0, 1, 0, 0, 0, 1},
+			{0, 0, 1, 1, 1, 0},
+			{1, 1, 0, 1, 0, 0},
+		},
+		B: []int{3, 5, 4, 7},
+		C: []int{1, 1, 1, 1, 1, 1},
+	}
+	sol, err := Minimize(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, p, sol, 10)
+}
+
+func TestMinimizeNeedsBranching(t *testing.T) {
+	p := Problem{
+		A: [][]int{{2, 2, -3}, {0, 0, 1}},
+		B: []int{0, 1},
+		C: []int{1, 1, 0},
+	}
+	// z = 1 leaves 2(x + y) = 3, which has rational but no integer solutions
+	if _, err := Minimize(p); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("Minimize() error = %v, want ErrInfeasible", err)
+	}
+
+	// 3x + 5y = 17, minimise x + y: the LP prefers y = 3.4
+	p = Problem{A: [][]int{{3, 5}}, B: []int{17}, C: []int{1, 1}}
+	sol, err := Minimize(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, p, sol, 5)
+	if sol.Nodes < 2 {
+		t.Errorf("solved in %d nodes without branching", sol.Nodes)
+	}
+	if want := big.NewRat(17, 5); sol.RootBound.Cmp(want) != 0 {
+		t.Errorf("RootBound = %v, want %v", sol.RootBound, want)
+	}
+}
+
+func TestMinimizeErrors(t *testing.T) {
+	if _, err := Minimize(Problem{A: [][]int{{2}}, B: []int{1}, C: []int{1}}); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("2x = 1: error = %v, want ErrInfeasible", err)
+	}
+	if _, err := Minimize(Problem{A: [][]int{{1, -1}}, B: []int{0}, C: []int{-1, 0}}); !errors.Is(err, ErrUnbounded) {
+		t.Errorf("min -x with x = y: error = %v, want ErrUnbounded", err)
+	}
+	if _, err := Minimize(Problem{A: [][]int{{1, 1}}, B: []int{1, 2}, C: []int{1, 1}}); err == nil {
+		t.Error("mismatched B accepted")
+	}
+}
+
+// fewestPresses solves min sum(x) for 0/1 button columns by dynamic
+// programming over every counter vector up to the target
+func fewestPresses(buttons [][]int, target []int) int {
+	size := 1
+	stride := make([]int, len(target))
+	for i, v := range target {
+		stride[i] = size
+		size *= v + 1
+	}
+	const inf = 1 << 30
+	best := make([]int, size)
+	for s := 1; s < size; s++ {
+		best[s] = inf
+		for _, b := range buttons {
+			prev, ok := s, true
+			for _, c := range b {
+				if (s/stride[c])%(target[c]+1) == 0 {
+					ok = false
+					break
+				}
+				prev -= stride[c]
+			}
+			if ok && len(b) > 0 {
+				best[s] = min(best[s], best[prev]+1)
+			}
+		}
+	}
+	if best[size-1] >= inf {
+		return -1
+	}
+	return best[size-1]
+}
+
+func TestMinimizeManyButtons(t *testing.T) {
+	// Far more buttons than counters leaves many free variables, which
+	// enumerating free-variable values cannot cope with
+	rng := rand.New(rand.NewPCG(10, 10))
+	for range 30 {
+		counters, nbuttons := 2+rng.IntN(3), 8+rng.IntN(25)
+		var buttons [][]int
+		for range nbuttons {
+			var b []int
+			for c := range counters {
+				if rng.IntN(2) == 0 {
+					b = append(b, c)
+				}
+			}
+			buttons = append(buttons, b)
+		}
+		target := make([]int, counters)
+		for i := range target {
+			target[i] = rng.IntN(12)
+		}
+
+		p := Problem{A: make([][]int, counters), B: target, C: make([]int, nbuttons)}
+		for i := range p.A {
+			p.A[i] = make([]int, nbuttons)
+		}
+		for j, b := range buttons {
+			p.C[j] = 1
+			for _, c := range b {
+				p.A[c][j] = 1
+			}
+		}
+
+		want := fewestPresses(buttons, target)
+		sol, err := Minimize(p)
+		if want < 0 {
+			if !errors.Is(err, ErrInfeasible) {
+				t.Fatalf("buttons %v target %v: error = %v, want ErrInfeasible", buttons, target, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("buttons %v target %v: %v", buttons, target, err)
+		}
+		check(t, p, sol, want)
+	}
+}
@@ -0,0 +1,173 @@
+package ilp
+
+import "math/big"
+
+// lpStatus is the outcome of solving a linear program
+type lpStatus int
+
+const (
+	lpOptimal lpStatus = iota
+	lpInfeasible
+	lpUnbounded
+)
+
+// tableau is a dense simplex tableau in exact arithmetic. Row i reads
+// sum(rows[i][j] * x_j) = rows[i][n], with basis[i] the variable it solves
+// for. obj holds the reduced costs and, in obj[n], minus the objective.
+type tableau struct {
+	rows  [][]big.Rat
+	obj   []big.Rat
+	basis []int
+	n     int
+}
+
+// pivot makes column c basic in row r
+func (t *tableau) pivot(r, c int) {
+	var inv, tmp, f big.Rat
+	row := t.rows[r]
+	inv.Inv(&row[c])
+	for j := range row {
+		row[j].Mul(&row[j], &inv)
+	}
+	eliminate := func(other []big.Rat) {
+		if other[c].Sign() == 0 {
+			return
+		}
+		f.Set(&other[c])
+		for j := range other {
+			other[j].Sub(&other[j], tmp.Mul(&f, &row[j]))
+		}
+	}
+	for i, other := range t.rows {
+		if i != r {
+			eliminate(other)
+		}
+	}
+	eliminate(t.obj)
+	t.basis[r] = c
+}
+
+// optimise runs the primal simplex method over the first limit columns.
+// Bland's rule (lowest index enters and leaves) rules out cycling.
+func (t *tableau) optimise(limit int) lpStatus {
+	var ratio, best big.Rat
+	for {
+		enter := -1
+		for j := range limit {
+			if t.obj[j].Sign() < 0 {
+				enter = j
+				break
+			}
+		}
+		if enter < 0 {
+			return lpOptimal
+		}
+
+		leave := -1
+		for i, row := range t.rows {
+			if row[enter].Sign() <= 0 {
+				continue
+			}
+			ratio.Quo(&row[t.n], &row[enter])
+			if leave < 0 {
+				best.Set(&ratio)
+				leave = i
+				continue
+			}
+			if cmp := ratio.Cmp(&best); cmp < 0 || (cmp == 0 && t.basis[i] < t.basis[leave]) {
+				best.Set(&ratio)
+				leave = i
+			}
+		}
+		if leave < 0 {
+			return lpUnbounded
+		}
+		t.pivot(leave, enter)
+	}
+}
+
+// solveLP minimises c·x subject to a x = b and x >= 0 with the two-phase
+// simplex method, returning the optimal x and objective value
+func solveLP(a [][]big.Rat, b, c []big.Rat) (lpStatus, []big.Rat, *big.Rat) {
+	m, n := len(a), len(c)
+
+	// Phase 1: one artificial variable per row, starting basic, with the
+	// right-hand sides made non-negative
+	t := &tableau{rows: make([][]big.Rat, m), obj: make([]big.Rat, n+m+1), basis: make([]int, m), n: n + m}
+	for i := range m {
+		row := make([]big.Rat, n+m+1)
+		neg := b[i].Sign() < 0
+		for j := range n {
+			row[j].Set(&a[i][j])
+			if neg {
+				row[j].Neg(&row[j])
+			}
+		}
+		row[n+i].SetInt64(1)
+		row[n+m].Abs(&b[i])
+		t.rows[i] = row
+		t.basis[i] = n + i
+		// Minimising the artificials' sum: price them out of the objective
+		for j := range row {
+			if j < n || j == n+m {
+				t.obj[j].Sub(&t.obj[j], &row[j])
+			}
+		}
+	}
+	t.optimise(n + m)
+	if t.obj[n+m].Sign() != 0 {
+		return lpInfeasible, nil, nil
+	}
+
+	// Drive artificials still basic at zero out of the basis; a row with no
+	// original column left is redundant and dropped
+	for i := 0; i < len(t.rows); i++ {
+		if t.basis[i] < n {
+			continue
+		}
+		col := -1
+		for j := range n {
+			if t.rows[i][j].Sign() != 0 {
+				col = j
+				break
+			}
+		}
+		if col >= 0 {
+			t.pivot(i, col)
+			continue
+		}
+		t.rows = append(t.rows[:i], t.rows[i+1:]...)
+		t.basis = append(t.basis[:i], t.basis[i+1:]...)
+		i--
+	}
+
+	// Phase 2: the real objective, priced against the current basis, with
+	// artificial columns barred from entering
+	for j := range t.obj {
+		t.obj[j].SetInt64(0)
+	}
+	for j := range n {
+		t.obj[j].Set(&c[j])
+	}
+	var tmp big.Rat
+	for i, bv := range t.basis {
+		if t.obj[bv].Sign() == 0 {
+			continue
+		}
+		var f big.Rat
+		f.Set(&t.obj[bv])
+		for j := range t.obj {
+			t.obj[j].Sub(&t.obj[j], tmp.Mul(&f, &t.rows[i][j]))
+		}
+	}
+	if t.optimise(n) == lpUnbounded {
+		return lpUnbounded, nil, nil
+	}
+
+	x := make([]big.Rat, n)
+	for i, bv := range t.basis {
+		x[bv].Set(&t.rows[i][n+m])
+	}
+	value := new(big.Rat).Neg(&t.obj[n+m])
+	return lpOptimal, x, value
+}
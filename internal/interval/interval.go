@@ -0,0 +1,199 @@
+// Package interval provides immutable sets of integers stored as sorted,
+// disjoint intervals.
+package interval
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Interval is the half-open integer range [Lo, Hi); it is empty when Hi <= Lo
+type Interval struct {
+	Lo, Hi int
+}
+
+// HalfOpen returns [lo, hi)
+func HalfOpen(lo, hi int) Interval {
+	return Interval{lo, hi}
+}
+
+// Closed returns [lo, hi] as the equivalent half-open interval [lo, hi+1)
+func Closed(lo, hi int) Interval {
+	if hi == math.MaxInt {
+		panic("interval: closed upper bound overflows")
+	}
+	return Interval{lo, hi + 1}
+}
+
+// Last returns the largest member, the upper bound in closed terms
+func (iv Interval) Last() int {
+	return iv.Hi - 1
+}
+
+// Empty reports whether iv has no members
+func (iv Interval) Empty() bool {
+	return iv.Hi <= iv.Lo
+}
+
+// Len returns the number of members
+func (iv Interval) Len() int {
+	return max(iv.Hi-iv.Lo, 0)
+}
+
+// Contains reports whether x lies in iv
+func (iv Interval) Contains(x int) bool {
+	return iv.Lo <= x && x < iv.Hi
+}
+
+func (iv Interval) String() string {
+	return fmt.Sprintf("[%d,%d)", iv.Lo, iv.Hi)
+}
+
+// Set is an immutable set of integers. The zero value is the empty set;
+// every operation returns a new Set and leaves its operands unchanged.
+//
+// Members are kept as sorted half-open intervals with gaps between them,
+// so intervals that overlap or merely touch are merged. For closed integer
+// ranges that is the usual "+1" adjacency rule: [3,5] and [6,9] become [3,9].
+type Set struct {
+	ivs []Interval
+}
+
+// New returns the union of the given intervals
+func New(ivs ...Interval) Set {
+	sorted := make([]Interval, 0, len(ivs))
+	for _, iv := range ivs {
+		if !iv.Empty() {
+			sorted = append(sorted, iv)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b Interval) int {
+		return cmp.Compare(a.Lo, b.Lo)
+	})
+
+	var merged []Interval
+	for _, iv := range sorted {
+		if n := len(merged); n > 0 && iv.Lo <= merged[n-1].Hi {
+			// Overlapping or adjacent, extend the interval
+			merged[n-1].Hi = max(merged[n-1].Hi, iv.Hi)
+		} else {
+			merged = append(merged, iv)
+		}
+	}
+	return Set{merged}
+}
+
+// Add returns s with iv's members added
+func (s Set) Add(iv Interval) Set {
+	return s.Union(New(iv))
+}
+
+// Remove returns s without iv's members
+func (s Set) Remove(iv Interval) Set {
+	return s.Difference(New(iv))
+}
+
+// Union returns the members of s or o
+func (s Set) Union(o Set) Set {
+	return New(slices.Concat(s.ivs, o.ivs)...)
+}
+
+// Intersect returns the members of both s and o
+func (s Set) Intersect(o Set) Set {
+	var out []Interval
+	i, j := 0, 0
+	for i < len(s.ivs) && j < len(o.ivs) {
+		a, b := s.ivs[i], o.ivs[j]
+		if lo, hi := max(a.Lo, b.Lo), min(a.Hi, b.Hi); lo < hi {
+			out = append(out, Interval{lo, hi})
+		}
+		// Advance whichever interval ends first
+		if a.Hi < b.Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return Set{out}
+}
+
+// Difference returns the members of s that are not in o
+func (s Set) Difference(o Set) Set {
+	var out []Interval
+	j := 0
+	for _, a := range s.ivs {
+		// Skip the parts of o that end before a starts
+		for j < len(o.ivs) && o.ivs[j].Hi <= a.Lo {
+			j++
+		}
+		lo := a.Lo
+		for k := j; k < len(o.ivs) && o.ivs[k].Lo < a.Hi; k++ {
+			if o.ivs[k].Lo > lo {
+				out = append(out, Interval{lo, o.ivs[k].Lo})
+			}
+			lo = max(lo, o.ivs[k].Hi)
+		}
+		if lo < a.Hi {
+			out = append(out, Interval{lo, a.Hi})
+		}
+	}
+	return Set{out}
+}
+
+// Complement returns the members of bounds that are not in s
+func (s Set) Complement(bounds Interval) Set {
+	return New(bounds).Difference(s)
+}
+
+// Contains reports whether x is a member, by binary search
+func (s Set) Contains(x int) bool {
+	// Find the first interval that ends after x
+	i, _ := slices.BinarySearchFunc(s.ivs, x, func(iv Interval, x int) int {
+		if iv.Hi <= x {
+			return -1
+		}
+		return 1
+	})
+	return i < len(s.ivs) && s.ivs[i].Lo <= x
+}
+
+// Len returns the total number of members
+func (s Set) Len() int {
+	n := 0
+	for _, iv := range s.ivs {
+		n += iv.Len()
+	}
+	return n
+}
+
+// Empty reports whether s has no members
+func (s Set) Empty() bool {
+	return len(s.ivs) == 0
+}
+
+// Intervals returns the number of disjoint intervals in s
+func (s Set) Intervals() int {
+	return len(s.ivs)
+}
+
+// All yields the intervals of s in increasing order
+func (s Set) All() iter.Seq[Interval] {
+	return slices.Values(s.ivs)
+}
+
+// Equal reports whether s and o have the same members
+func (s Set) Equal(o Set) bool {
+	return slices.Equal(s.ivs, o.ivs)
+}
+
+func (s Set) String() string {
+	parts := make([]string, len(s.ivs))
+	for i, iv := range s.ivs {
+		parts[i] = iv.String()
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
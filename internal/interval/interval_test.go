@@ -0,0 +1,127 @@
+package interval
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestNewMerges(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []Interval
+		want string
+	}{
+		{"empty", nil, "{}"},
+		{"drops empty", []Interval{HalfOpen(3, 3), HalfOpen(5, 4)}, "{}"},
+		{"overlap", []Interval{HalfOpen(5, 9), HalfOpen(1, 6)}, "{[1,9)}"},
+		{"half-open touching", []Interval{HalfOpen(1, 3), HalfOpen(3, 5)}, "{[1,5)}"},
+		{"half-open gap", []Interval{HalfOpen(1, 3), HalfOpen(4, 5)}, "{[1,3) [4,5)}"},
+		{"closed adjacent", []Interval{Closed(3, 5), Closed(6, 9)}, "{[3,10)}"},
+		{"closed gap", []Interval{Closed(3, 5), Closed(7, 9)}, "{[3,6) [7,10)}"},
+		{"day05 example", []Interval{Closed(3, 5), Closed(10, 14), Closed(16, 20), Closed(12, 18)}, "{[3,6) [10,21)}"},
+	}
+	for _, tc := range tests {
+		if got := New(tc.in...).String(); got != tc.want {
+			t.Errorf("%s: New() = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestClosed(t *testing.T) {
+	iv := Closed(10, 14)
+	if iv.Len() != 5 || iv.Last() != 14 || !iv.Contains(14) || iv.Contains(15) {
+		t.Errorf("Closed(10, 14) = %v: Len %d, Last %d", iv, iv.Len(), iv.Last())
+	}
+	s := New(Closed(3, 5), Closed(10, 14), Closed(16, 20), Closed(12, 18))
+	if s.Len() != 14 {
+		t.Errorf("Len() = %d, want 14", s.Len())
+	}
+	var fresh []int
+	for _, n := range []int{1, 5, 8, 11, 17, 32} {
+		if s.Contains(n) {
+			fresh = append(fresh, n)
+		}
+	}
+	if !slices.Equal(fresh, []int{5, 11, 17}) {
+		t.Errorf("Contains matched %v, want [5 11 17]", fresh)
+	}
+}
+
+func TestImmutable(t *testing.T) {
+	s := New(HalfOpen(0, 10))
+	s.Add(HalfOpen(20, 30))
+	s.Remove(HalfOpen(2, 4))
+	s.Union(New(HalfOpen(-5, 0)))
+	if got := s.String(); got != "{[0,10)}" {
+		t.Errorf("operations modified the receiver: %s", got)
+	}
+}
+
+// members lists the members of s within [lo, hi)
+func members(s Set, lo, hi int) []bool {
+	out := make([]bool, hi-lo)
+	for iv := range s.All() {
+		for x := max(iv.Lo, lo); x < min(iv.Hi, hi); x++ {
+			out[x-lo] = true
+		}
+	}
+	return out
+}
+
+func randomSet(rng *rand.Rand) Set {
+	var ivs []Interval
+	for range rng.IntN(5) {
+		lo := rng.IntN(40) - 5
+		ivs = append(ivs, HalfOpen(lo, lo+rng.IntN(8)))
+	}
+	return New(ivs...)
+}
+
+// TestAgainstBitmap checks every operation against a plain membership
+// table over a small universe.
+func TestAgainstBitmap(t *testing.T) {
+	const lo, hi = -10, 50
+	rng := rand.New(rand.NewPCG(7, 11))
+	bounds := HalfOpen(0, 30)
+	for range 500 {
+		a, b := randomSet(rng), randomSet(rng)
+		ma, mb := members(a, lo, hi), members(b, lo, hi)
+		ops := []struct {
+			name string
+			got  Set
+			want func(x, y, in bool) bool
+		}{
+			{"Union", a.Union(b), func(x, y, _ bool) bool { return x || y }},
+			{"Intersect", a.Intersect(b), func(x, y, _ bool) bool { return x && y }},
+			{"Difference", a.Difference(b), func(x, y, _ bool) bool { return x && !y }},
+			{"Complement", a.Complement(bounds), func(x, _, in bool) bool { return in && !x }},
+		}
+		for _, op := range ops {
+			got := members(op.got, lo, hi)
+			for i := range got {
+				x := lo + i
+				if want := op.want(ma[i], mb[i], bounds.Contains(x)); got[i] != want {
+					t.Fatalf("%v.%s(%v) = %v: member %d is %v, want %v", a, op.name, b, op.got, x, got[i], want)
+				}
+			}
+			// Results must stay in canonical form so Equal is meaningful
+			if !op.got.Equal(New(slices.Collect(op.got.All())...)) {
+				t.Fatalf("%v.%s(%v) = %v is not canonical", a, op.name, b, op.got)
+			}
+		}
+
+		n := 0
+		for i := range ma {
+			if a.Contains(lo+i) != ma[i] {
+				t.Fatalf("%v.Contains(%d) = %v", a, lo+i, !ma[i])
+			}
+			if ma[i] {
+				n++
+			}
+		}
+		if a.Len() != n {
+			t.Fatalf("%v.Len() = %d, want %d", a, a.Len(), n)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Package linalg provides linear algebra over GF(2).
+package linalg
+
+import "math/bits"
+
+// Bits is a vector over GF(2) packed 64 entries to a word
+type Bits []uint64
+
+// NewBits returns a zero vector with room for n entries
+func NewBits(n int) Bits {
+	return make(Bits, (n+63)/64)
+}
+
+// Get returns entry i
+func (b Bits) Get(i int) bool {
+	return b[i/64]>>(i%64)&1 == 1
+}
+
+// Set stores entry i
+func (b Bits) Set(i int, v bool) {
+	if v {
+		b[i/64] |= 1 << (i % 64)
+	} else {
+		b[i/64] &^= 1 << (i % 64)
+	}
+}
+
+// Xor adds o to b in place
+func (b Bits) Xor(o Bits) {
+	for i := range b {
+		b[i] ^= o[i]
+	}
+}
+
+// OnesCount returns the number of set entries
+func (b Bits) OnesCount() int {
+	n := 0
+	for _, w := range b {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Clone returns a copy of b
+func (b Bits) Clone() Bits {
+	return append(Bits(nil), b...)
+}
+
+// GF2 is a matrix over GF(2) stored as packed rows
+type GF2 struct {
+	rows []Bits
+	cols int
+}
+
+// NewGF2 returns a rows x cols zero matrix
+func NewGF2(rows, cols int) *GF2 {
+	m := &GF2{rows: make([]Bits, rows), cols: cols}
+	for r := range m.rows {
+		m.rows[r] = NewBits(cols)
+	}
+	return m
+}
+
+// Rows returns the number of rows
+func (m *GF2) Rows() int {
+	return len(m.rows)
+}
+
+// Cols returns the number of columns
+func (m *GF2) Cols() int {
+	return m.cols
+}
+
+// Get returns the entry at row r, column c
+func (m *GF2) Get(r, c int) bool {
+	return m.rows[r].Get(c)
+}
+
+// Set stores the entry at row r, column c
+func (m *GF2) Set(r, c int, v bool) {
+	m.rows[r].Set(c, v)
+}
+
+// Row returns row r, sharing storage with m
+func (m *GF2) Row(r int) Bits {
+	return m.rows[r]
+}
+
+// Clone returns a copy of m
+func (m *GF2) Clone() *GF2 {
+	c := &GF2{rows: make([]Bits, len(m.rows)), cols: m.cols}
+	for r, row := range m.rows {
+		c.rows[r] = row.Clone()
+	}
+	return c
+}
+
+// RREF reduces m in place to reduced row echelon form and returns the
+// pivot column of each nonzero row; its length is the rank
+func (m *GF2) RREF() []int {
+	var pivots []int
+	for c := 0; c < m.cols && len(pivots) < len(m.rows); c++ {
+		pr := len(pivots)
+		found := -1
+		for r := pr; r < len(m.rows); r++ {
+			if m.rows[r].Get(c) {
+				found = r
+				break
+			}
+		}
+		if found < 0 {
+			continue
+		}
+		m.rows[pr], m.rows[found] = m.rows[found], m.rows[pr]
+		for r := range m.rows {
+			if r != pr && m.rows[r].Get(c) {
+				m.rows[r].Xor(m.rows[pr])
+			}
+		}
+		pivots = append(pivots, c)
+	}
+	return pivots
+}
+
+// Rank returns the rank of m
+func (m *GF2) Rank() int {
+	return len(m.Clone().RREF())
+}
+
+// NullSpace returns a basis of the solutions of m x = 0, one vector per
+// free column with that column set
+func (m *GF2) NullSpace() []Bits {
+	r := m.Clone()
+	pivots := r.RREF()
+	isPivot := make([]bool, m.cols)
+	for _, c := range pivots {
+		isPivot[c] = true
+	}
+	var basis []Bits
+	for f := range m.cols {
+		if isPivot[f] {
+			continue
+		}
+		v := NewBits(m.cols)
+		v.Set(f, true)
+		for row, c := range pivots {
+			if r.rows[row].Get(f) {
+				v.Set(c, true)
+			}
+		}
+		basis = append(basis, v)
+	}
+	return basis
+}
+
+// MulVec returns m x
+func (m *GF2) MulVec(x Bits) Bits {
+	out := NewBits(len(m.rows))
+	for r, row := range m.rows {
+		parity := 0
+		for i, w := range row {
+			parity ^= bits.OnesCount64(w & x[i])
+		}
+		out.Set(r, parity&1 == 1)
+	}
+	return out
+}
+
+// Solve returns a solution of m x = b with every free variable zero, or
+// false when the system is inconsistent
+func (m *GF2) Solve(b Bits) (Bits, bool) {
+	aug := NewGF2(len(m.rows), m.cols+1)
+	for r, row := range m.rows {
+		copy(aug.rows[r], row)
+		aug.rows[r].Set(m.cols, b.Get(r))
+	}
+	pivots := aug.RREF()
+	if n := len(pivots); n > 0 && pivots[n-1] == m.cols {
+		return nil, false
+	}
+	x := NewBits(m.cols)
+	for row, c := range pivots {
+		x.Set(c, aug.rows[row].Get(m.cols))
+	}
+	return x, true
+}
@@ -0,0 +1,97 @@
+package linalg
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestBits(t *testing.T) {
+	b := NewBits(130)
+	if len(b) != 3 {
+		t.Fatalf("NewBits(130) has %d words, want 3", len(b))
+	}
+	for _, i := range []int{0, 63, 64, 129} {
+		b.Set(i, true)
+	}
+	b.Set(63, false)
+	if !b.Get(0) || b.Get(63) || !b.Get(64) || !b.Get(129) || b.OnesCount() != 3 {
+		t.Errorf("bits = %x", b)
+	}
+	c := b.Clone()
+	c.Xor(b)
+	if c.OnesCount() != 0 || b.OnesCount() != 3 {
+		t.Error("Xor of a vector with itself is not zero, or modified the operand")
+	}
+}
+
+func TestGF2RREF(t *testing.T) {
+	// Rows: 110, 011, 101 - the third is the sum of the first two
+	m := NewGF2(3, 3)
+	for _, e := range [][2]int{{0, 0}, {0, 1}, {1, 1}, {1, 2}, {2, 0}, {2, 2}} {
+		m.Set(e[0], e[1], true)
+	}
+	if m.Rank() != 2 {
+		t.Errorf("Rank() = %d, want 2", m.Rank())
+	}
+	r := m.Clone()
+	if pivots := r.RREF(); !slices.Equal(pivots, []int{0, 1}) {
+		t.Errorf("RREF() pivots = %v, want [0 1]", pivots)
+	}
+	want := [][]bool{{true, false, true}, {false, true, true}, {false, false, false}}
+	for i := range 3 {
+		for j := range 3 {
+			if r.Get(i, j) != want[i][j] {
+				t.Errorf("RREF entry (%d,%d) = %v, want %v", i, j, r.Get(i, j), want[i][j])
+			}
+		}
+	}
+
+	b := NewBits(3)
+	b.Set(2, true)
+	if _, ok := m.Solve(b); ok {
+		t.Error("Solve found a solution to an inconsistent system")
+	}
+}
+
+func randomGF2(rng *rand.Rand, rows, cols int) *GF2 {
+	m := NewGF2(rows, cols)
+	for r := range rows {
+		for c := range cols {
+			m.Set(r, c, rng.IntN(3) == 0)
+		}
+	}
+	return m
+}
+
+func TestGF2Random(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	for range 200 {
+		rows, cols := 1+rng.IntN(12), 1+rng.IntN(140)
+		m := randomGF2(rng, rows, cols)
+
+		null := m.NullSpace()
+		if m.Rank()+len(null) != cols {
+			t.Fatalf("rank %d + nullity %d != %d columns", m.Rank(), len(null), cols)
+		}
+		for _, v := range null {
+			if m.MulVec(v).OnesCount() != 0 {
+				t.Fatalf("null-space vector %x is not in the kernel", v)
+			}
+		}
+
+		// A right-hand side built from a known x is always consistent
+		x := NewBits(cols)
+		for c := range cols {
+			x.Set(c, rng.IntN(2) == 0)
+		}
+		b := m.MulVec(x)
+		got, ok := m.Solve(b)
+		if !ok {
+			t.Fatal("Solve reported a consistent system as inconsistent")
+		}
+		if !slices.Equal(m.MulVec(got), b) {
+			t.Fatalf("Solve returned %x, which does not satisfy the system", got)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// Package sat builds formulas in conjunctive normal form, reads and writes
+// them as DIMACS, and decides them with a small CDCL solver.
+package sat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CNF is a conjunction of clauses. Literals follow DIMACS: variables are
+// numbered from 1, v is the literal "v is true" and -v its negation.
+type CNF struct {
+	Vars     int
+	Clauses  [][]int
+	Comments []string
+}
+
+// NewVar adds a variable and returns its number
+func (f *CNF) NewVar() int {
+	f.Vars++
+	return f.Vars
+}
+
+// Add adds the clause lits, which must only use variables already added
+func (f *CNF) Add(lits ...int) {
+	for _, l := range lits {
+		if l == 0 || abs(l) > f.Vars {
+			panic(fmt.Sprintf("sat: literal %d out of range for %d variables", l, f.Vars))
+		}
+	}
+	f.Clauses = append(f.Clauses, append([]int(nil), lits...))
+}
+
+// AtMostOne adds clauses allowing at most one of lits to be true: pairwise
+// for a handful of literals and a sequential counter beyond that
+func (f *CNF) AtMostOne(lits []int) {
+	if len(lits) > 5 {
+		f.AtMostK(lits, 1)
+		return
+	}
+	for i, a := range lits {
+		for _, b := range lits[i+1:] {
+			f.Add(-a, -b)
+		}
+	}
+}
+
+// AtMostK adds Sinz's sequential counter: auxiliary variable s[i][j] is
+// forced true when at least j+1 of lits[0..i] are, and lits[i] is barred
+// when s[i-1][k-1] already is
+func (f *CNF) AtMostK(lits []int, k int) {
+	n := len(lits)
+	switch {
+	case k < 0:
+		f.Add()
+		return
+	case k >= n:
+		return
+	case k == 0:
+		for _, l := range lits {
+			f.Add(-l)
+		}
+		return
+	}
+
+	prev := make([]int, k)
+	for j := range prev {
+		prev[j] = f.NewVar()
+	}
+	f.Add(-lits[0], prev[0])
+	for j := 1; j < k; j++ {
+		f.Add(-prev[j])
+	}
+	for i := 1; i < n-1; i++ {
+		s := make([]int, k)
+		for j := range s {
+			s[j] = f.NewVar()
+		}
+		f.Add(-lits[i], s[0])
+		f.Add(-prev[0], s[0])
+		for j := 1; j < k; j++ {
+			f.Add(-lits[i], -prev[j-1], s[j])
+			f.Add(-prev[j], s[j])
+		}
+		f.Add(-lits[i], -prev[k-1])
+		prev = s
+	}
+	f.Add(-lits[n-1], -prev[k-1])
+}
+
+// AtLeastK adds a sequential counter the other way round: auxiliary
+// variable s[i][j] may only be true when at least j+1 of lits[0..i] are,
+// and s[n-1][k-1] must be true
+func (f *CNF) AtLeastK(lits []int, k int) {
+	n := len(lits)
+	switch {
+	case k <= 0:
+		return
+	case k > n:
+		f.Add()
+		return
+	}
+
+	prev := make([]int, k)
+	for j := range prev {
+		prev[j] = f.NewVar()
+	}
+	f.Add(-prev[0], lits[0])
+	for j := 1; j < k; j++ {
+		f.Add(-prev[j])
+	}
+	for i := 1; i < n; i++ {
+		s := make([]int, k)
+		for j := range s {
+			s[j] = f.NewVar()
+		}
+		f.Add(-s[0], prev[0], lits[i])
+		for j := 1; j < k; j++ {
+			f.Add(-s[j], prev[j], lits[i])
+			f.Add(-s[j], prev[j], prev[j-1])
+		}
+		prev = s
+	}
+	f.Add(prev[k-1])
+}
+
+// ExactlyK adds clauses requiring exactly k of lits to be true
+func (f *CNF) ExactlyK(lits []int, k int) {
+	f.AtMostK(lits, k)
+	f.AtLeastK(lits, k)
+}
+
+// WriteDIMACS writes f in DIMACS CNF format, comments first
+func (f *CNF) WriteDIMACS(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, c := range f.Comments {
+		fmt.Fprintf(bw, "c %s\n", c)
+	}
+	fmt.Fprintf(bw, "p cnf %d %d\n", f.Vars, len(f.Clauses))
+	for _, clause := range f.Clauses {
+		for _, l := range clause {
+			bw.WriteString(strconv.Itoa(l))
+			bw.WriteByte(' ')
+		}
+		bw.WriteString("0\n")
+	}
+	return bw.Flush()
+}
+
+// ParseDIMACS reads a formula in DIMACS CNF format. Clauses may span lines;
+// a line holding only "%" ends the input, as in the SATLIB benchmarks.
+func ParseDIMACS(r io.Reader) (*CNF, error) {
+	f := &CNF{}
+	header := false
+	declared := 0
+	var clause []int
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+lines:
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "":
+		case line == "%":
+			break lines
+		case line[0] == 'c':
+			f.Comments = append(f.Comments, strings.TrimSpace(line[1:]))
+		case line[0] == 'p':
+			fields := strings.Fields(line)
+			if header || len(fields) != 4 || fields[1] != "cnf" {
+				return nil, fmt.Errorf("sat: line %d: bad header %q", lineNo, line)
+			}
+			vars, err1 := strconv.Atoi(fields[2])
+			clauses, err2 := strconv.Atoi(fields[3])
+			if err1 != nil || err2 != nil || vars < 0 || clauses < 0 {
+				return nil, fmt.Errorf("sat: line %d: bad header %q", lineNo, line)
+			}
+			f.Vars, declared, header = vars, clauses, true
+		default:
+			if !header {
+				return nil, fmt.Errorf("sat: line %d: clause before \"p cnf\" header", lineNo)
+			}
+			for _, field := range strings.Fields(line) {
+				l, err := strconv.Atoi(field)
+				if err != nil {
+					return nil, fmt.Errorf("sat: line %d: bad literal %q", lineNo, field)
+				}
+				if abs(l) > f.Vars {
+					return nil, fmt.Errorf("sat: line %d: literal %d out of range for %d variables", lineNo, l, f.Vars)
+				}
+				if l == 0 {
+					f.Clauses = append(f.Clauses, clause)
+					clause = nil
+				} else {
+					clause = append(clause, l)
+				}
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, fmt.Errorf("sat: missing \"p cnf\" header")
+	}
+	if clause != nil {
+		f.Clauses = append(f.Clauses, clause)
+	}
+	if len(f.Clauses) != declared {
+		return nil, fmt.Errorf("sat: header declares %d clauses, found %d", declared, len(f.Clauses))
+	}
+	return f, nil
+}
+
+// Satisfies reports whether the assignment, indexed by variable, makes every
+// clause true
+func (f *CNF) Satisfies(model []bool) bool {
+next:
+	for _, clause := range f.Clauses {
+		for _, l := range clause {
+			if model[abs(l)] == (l > 0) {
+				continue next
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
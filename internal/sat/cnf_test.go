@@ -0,0 +1,130 @@
+package sat
+
+import (
+	"bytes"
+	"math/bits"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCardinality(t *testing.T) {
+	encodings := []struct {
+		name  string
+		add   func(f *CNF, lits []int, k int)
+		holds func(ones, k int) bool
+	}{
+		{"AtMostK", (*CNF).AtMostK, func(ones, k int) bool { return ones <= k }},
+		{"AtLeastK", (*CNF).AtLeastK, func(ones, k int) bool { return ones >= k }},
+		{"ExactlyK", (*CNF).ExactlyK, func(ones, k int) bool { return ones == k }},
+	}
+	for _, enc := range encodings {
+		for n := range 7 {
+			for k := -1; k <= n+1; k++ {
+				f := &CNF{}
+				lits := make([]int, n)
+				for i := range lits {
+					lits[i] = f.NewVar()
+				}
+				enc.add(f, lits, k)
+				// Every assignment of the counted variables must extend to a
+				// model exactly when the constraint holds
+				for mask := range 1 << n {
+					g := &CNF{Vars: f.Vars, Clauses: f.Clauses}
+					for i, v := range lits {
+						if mask>>i&1 == 1 {
+							g.Add(v)
+						} else {
+							g.Add(-v)
+						}
+					}
+					want := enc.holds(bits.OnesCount(uint(mask)), k)
+					if got := New(g).Solve(); got != want {
+						t.Fatalf("%s(n=%d, k=%d) with %0*b: satisfiable = %v, want %v", enc.name, n, k, n, mask, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAtMostOne(t *testing.T) {
+	for n := range 9 {
+		f := &CNF{}
+		lits := make([]int, n)
+		for i := range lits {
+			lits[i] = f.NewVar()
+		}
+		f.AtMostOne(lits)
+		for mask := range 1 << n {
+			g := &CNF{Vars: f.Vars, Clauses: f.Clauses}
+			for i, v := range lits {
+				if mask>>i&1 == 1 {
+					g.Add(v)
+				} else {
+					g.Add(-v)
+				}
+			}
+			if got, want := New(g).Solve(), bits.OnesCount(uint(mask)) <= 1; got != want {
+				t.Fatalf("AtMostOne(n=%d) with %0*b: satisfiable = %v, want %v", n, n, mask, got, want)
+			}
+		}
+	}
+}
+
+func TestDIMACSRoundTrip(t *testing.T) {
+	f := &CNF{Comments: []string{"region 4x4", "two presents"}}
+	a, b, c := f.NewVar(), f.NewVar(), f.NewVar()
+	f.Add(a, -b)
+	f.Add(b, c, -a)
+	f.Add()
+	f.Add(-c)
+
+	var buf bytes.Buffer
+	if err := f.WriteDIMACS(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "c region 4x4\nc two presents\np cnf 3 4\n1 -2 0\n2 3 -1 0\n0\n-3 0\n"
+	if buf.String() != want {
+		t.Errorf("WriteDIMACS() =\n%s\nwant\n%s", buf.String(), want)
+	}
+	g, err := ParseDIMACS(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Vars != f.Vars || len(g.Clauses) != len(f.Clauses) || !reflect.DeepEqual(g.Comments, f.Comments) {
+		t.Fatalf("ParseDIMACS() = %+v, want %+v", g, f)
+	}
+	for i := range f.Clauses {
+		if len(f.Clauses[i]) != len(g.Clauses[i]) || (len(f.Clauses[i]) > 0 && !reflect.DeepEqual(f.Clauses[i], g.Clauses[i])) {
+			t.Errorf("clause %d = %v, want %v", i, g.Clauses[i], f.Clauses[i])
+		}
+	}
+}
+
+func TestParseDIMACS(t *testing.T) {
+	// Clauses may span lines and "%" ends the input
+	in := "c SATLIB style\np cnf 3 2\n 1 -3\n 2 0 -1\n 3 0\n%\n0\n"
+	f, err := ParseDIMACS(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]int{{1, -3, 2}, {-1, 3}}
+	if !reflect.DeepEqual(f.Clauses, want) {
+		t.Errorf("clauses = %v, want %v", f.Clauses, want)
+	}
+
+	bad := map[string]string{
+		"no header":        "1 2 0\n",
+		"bad header":       "p dnf 2 1\n1 0\n",
+		"two headers":      "p cnf 2 1\np cnf 2 1\n1 0\n",
+		"bad literal":      "p cnf 2 1\n1 x 0\n",
+		"out of range":     "p cnf 2 1\n3 0\n",
+		"too many clauses": "p cnf 2 1\n1 0\n2 0\n",
+	}
+	for name, in := range bad {
+		if _, err := ParseDIMACS(strings.NewReader(in)); err == nil {
+			t.Errorf("%s: ParseDIMACS() succeeded", name)
+		}
+	}
+}
@@ -0,0 +1,537 @@
+package sat
+
+import (
+	"cmp"
+	"slices"
+)
+
+// lit is a literal numbered for indexing: variable v (from 0) is 2v when
+// true and 2v+1 when negated
+type lit int
+
+func fromDIMACS(l int) lit {
+	if l < 0 {
+		return lit(2*(-l-1) + 1)
+	}
+	return lit(2 * (l - 1))
+}
+
+func (l lit) variable() int { return int(l >> 1) }
+func (l lit) not() lit      { return l ^ 1 }
+
+// Stats counts the work done by Solve
+type Stats struct {
+	Decisions    int
+	Propagations int
+	Conflicts    int
+	Learnt       int
+	Restarts     int
+	Reductions   int
+}
+
+// Solver decides a CNF formula by conflict-driven clause learning: two
+// watched literals per clause for unit propagation, first-UIP conflict
+// analysis with clause minimisation and non-chronological backjumping,
+// VSIDS branching with phase saving, restarts on the Luby sequence, and
+// periodic deletion of the learnt clauses spanning the most decision levels.
+type Solver struct {
+	clauses [][]lit // nil once a learnt clause is deleted
+	watches [][]int // clauses watching each literal, visited when it becomes false
+
+	learnts    []int // indices of live learnt clauses
+	lbd        []int // by clause: decision levels spanned when learnt
+	nextReduce int   // conflict count at which to halve the learnt clauses
+	levelStamp []int // scratch for counting levels
+
+	assign   []int8 // by variable: 1 true, -1 false, 0 unassigned
+	level    []int
+	reason   []int  // clause that implied each variable, -1 for decisions
+	phase    []bool // last value of each variable, tried first when branching
+	trail    []lit
+	trailLim []int // trail length at the start of each decision level
+	qhead    int
+
+	activity []float64
+	inc      float64
+	order    varHeap
+	seen     []bool
+
+	unsat bool
+	model []bool
+	stats Stats
+}
+
+const (
+	// restartBase is the number of conflicts in one unit of the Luby sequence
+	restartBase = 100
+	// reduceFirst and reduceStep space out learnt clause deletions: the nth
+	// happens after reduceFirst + (n-1)*reduceStep more conflicts
+	reduceFirst = 2000
+	reduceStep  = 300
+)
+
+// New returns a solver for f
+func New(f *CNF) *Solver {
+	n := f.Vars
+	s := &Solver{
+		watches:  make([][]int, 2*n),
+		assign:   make([]int8, n),
+		level:    make([]int, n),
+		reason:   make([]int, n),
+		phase:    make([]bool, n),
+		activity: make([]float64, n),
+		inc:      1,
+		seen:     make([]bool, n),
+
+		nextReduce: reduceFirst,
+		levelStamp: make([]int, n+1),
+	}
+	s.order = varHeap{activity: s.activity, pos: make([]int, n)}
+	for v := range n {
+		s.reason[v] = -1
+		s.order.pos[v] = -1
+		s.order.push(v)
+	}
+	for _, clause := range f.Clauses {
+		s.addClause(clause)
+	}
+	return s
+}
+
+// addClause adds an input clause before search starts. Units are put on the
+// trail and propagated by the first call to propagate.
+func (s *Solver) addClause(clause []int) {
+	if s.unsat {
+		return
+	}
+	var c []lit
+next:
+	for _, d := range clause {
+		l := fromDIMACS(d)
+		for _, m := range c {
+			if m == l {
+				continue next
+			}
+			if m == l.not() {
+				return // always true
+			}
+		}
+		c = append(c, l)
+	}
+	switch len(c) {
+	case 0:
+		s.unsat = true
+	case 1:
+		switch s.value(c[0]) {
+		case -1:
+			s.unsat = true
+		case 0:
+			s.enqueue(c[0], -1)
+		}
+	default:
+		s.attach(c)
+	}
+}
+
+// attach adds c to the clause list, watching its first two literals
+func (s *Solver) attach(c []lit) int {
+	ci := len(s.clauses)
+	s.clauses = append(s.clauses, c)
+	s.lbd = append(s.lbd, 0)
+	s.watches[c[0]] = append(s.watches[c[0]], ci)
+	s.watches[c[1]] = append(s.watches[c[1]], ci)
+	return ci
+}
+
+func (s *Solver) value(l lit) int8 {
+	if l&1 == 1 {
+		return -s.assign[l.variable()]
+	}
+	return s.assign[l.variable()]
+}
+
+func (s *Solver) decisionLevel() int {
+	return len(s.trailLim)
+}
+
+// enqueue makes l true, implied by clause reason or decided when it is -1.
+// An implying clause always has l first.
+func (s *Solver) enqueue(l lit, reason int) {
+	v := l.variable()
+	s.assign[v] = 1
+	if l&1 == 1 {
+		s.assign[v] = -1
+	}
+	s.level[v] = s.decisionLevel()
+	s.reason[v] = reason
+	s.trail = append(s.trail, l)
+}
+
+// propagate assigns every literal forced by unit clauses and returns a
+// clause made false, or -1 if there is none
+func (s *Solver) propagate() int {
+	for s.qhead < len(s.trail) {
+		falsified := s.trail[s.qhead].not()
+		s.qhead++
+		s.stats.Propagations++
+
+		ws := s.watches[falsified]
+		kept := 0
+	clauses:
+		for i, ci := range ws {
+			c := s.clauses[ci]
+			if c == nil {
+				continue // deleted: drop the watch
+			}
+			// Keep the falsified watch second
+			if c[0] == falsified {
+				c[0], c[1] = c[1], c[0]
+			}
+			if s.value(c[0]) == 1 {
+				ws[kept] = ci
+				kept++
+				continue
+			}
+			for k := 2; k < len(c); k++ {
+				if s.value(c[k]) != -1 {
+					c[1], c[k] = c[k], c[1]
+					s.watches[c[1]] = append(s.watches[c[1]], ci)
+					continue clauses
+				}
+			}
+			ws[kept] = ci
+			kept++
+			if s.value(c[0]) == -1 {
+				kept += copy(ws[kept:], ws[i+1:])
+				s.watches[falsified] = ws[:kept]
+				s.qhead = len(s.trail)
+				return ci
+			}
+			s.enqueue(c[0], ci)
+		}
+		s.watches[falsified] = ws[:kept]
+	}
+	return -1
+}
+
+// analyze derives the first-UIP clause from conflict: it resolves the
+// conflict with the reasons of current-level literals, latest first, until
+// one current-level literal is left. It returns the learnt clause, with the
+// asserting literal first and the highest other level second, and the level
+// to jump back to.
+func (s *Solver) analyze(conflict int) ([]lit, int) {
+	learnt := []lit{0}
+	pending := 0 // current-level literals seen but not yet resolved
+	var p lit = -1
+	i := len(s.trail) - 1
+	for {
+		c := s.clauses[conflict]
+		start := 0
+		if p >= 0 {
+			start = 1 // c[0] is p itself
+		}
+		for _, q := range c[start:] {
+			v := q.variable()
+			if s.seen[v] || s.level[v] == 0 {
+				continue
+			}
+			s.seen[v] = true
+			s.bump(v)
+			if s.level[v] == s.decisionLevel() {
+				pending++
+			} else {
+				learnt = append(learnt, q)
+			}
+		}
+		for !s.seen[s.trail[i].variable()] {
+			i--
+		}
+		p = s.trail[i]
+		i--
+		s.seen[p.variable()] = false
+		pending--
+		if pending == 0 {
+			break
+		}
+		conflict = s.reason[p.variable()]
+	}
+	learnt[0] = p.not()
+
+	// Drop literals implied by the others: those whose reason clause has
+	// only literals already in the clause or fixed at level 0
+	all := slices.Clone(learnt[1:])
+	kept := 1
+	for _, q := range learnt[1:] {
+		if !s.redundant(q) {
+			learnt[kept] = q
+			kept++
+		}
+	}
+	learnt = learnt[:kept]
+	for _, q := range all {
+		s.seen[q.variable()] = false
+	}
+
+	back := 0
+	for k := 1; k < len(learnt); k++ {
+		if lv := s.level[learnt[k].variable()]; lv > back {
+			back = lv
+			learnt[1], learnt[k] = learnt[k], learnt[1]
+		}
+	}
+	return learnt, back
+}
+
+// redundant reports whether q, a literal of the clause being learnt, is
+// implied by a reason whose other literals are all in the clause
+func (s *Solver) redundant(q lit) bool {
+	r := s.reason[q.variable()]
+	if r < 0 {
+		return false
+	}
+	for _, x := range s.clauses[r][1:] {
+		if v := x.variable(); !s.seen[v] && s.level[v] > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// levels returns the number of distinct decision levels in c
+func (s *Solver) levels(c []lit) int {
+	stamp := s.stats.Conflicts
+	n := 0
+	for _, l := range c {
+		if lv := s.level[l.variable()]; s.levelStamp[lv] != stamp {
+			s.levelStamp[lv] = stamp
+			n++
+		}
+	}
+	return n
+}
+
+// reduce deletes the half of the learnt clauses spanning the most levels,
+// keeping those over at most two levels and those that are reasons
+func (s *Solver) reduce() {
+	slices.SortStableFunc(s.learnts, func(a, b int) int { return cmp.Compare(s.lbd[b], s.lbd[a]) })
+	half := len(s.learnts) / 2
+	kept := s.learnts[:0]
+	for i, ci := range s.learnts {
+		c := s.clauses[ci]
+		locked := s.reason[c[0].variable()] == ci && s.value(c[0]) == 1
+		if i < half && s.lbd[ci] > 2 && !locked {
+			s.clauses[ci] = nil
+			continue
+		}
+		kept = append(kept, ci)
+	}
+	s.learnts = kept
+}
+
+// bump raises v's activity, rescaling every activity before it overflows
+func (s *Solver) bump(v int) {
+	s.activity[v] += s.inc
+	if s.activity[v] > 1e100 {
+		for i := range s.activity {
+			s.activity[i] *= 1e-100
+		}
+		s.inc *= 1e-100
+	}
+	s.order.update(v)
+}
+
+// backtrack undoes every assignment above level
+func (s *Solver) backtrack(level int) {
+	if s.decisionLevel() <= level {
+		return
+	}
+	for i := len(s.trail) - 1; i >= s.trailLim[level]; i-- {
+		v := s.trail[i].variable()
+		s.phase[v] = s.assign[v] == 1
+		s.assign[v] = 0
+		s.reason[v] = -1
+		if s.order.pos[v] < 0 {
+			s.order.push(v)
+		}
+	}
+	s.trail = s.trail[:s.trailLim[level]]
+	s.trailLim = s.trailLim[:level]
+	s.qhead = len(s.trail)
+}
+
+// decide picks the most active unassigned variable and its saved phase,
+// returning false when every variable is assigned
+func (s *Solver) decide() bool {
+	for s.order.len() > 0 {
+		v := s.order.pop()
+		if s.assign[v] != 0 {
+			continue
+		}
+		s.stats.Decisions++
+		s.trailLim = append(s.trailLim, len(s.trail))
+		l := lit(2 * v)
+		if !s.phase[v] {
+			l++
+		}
+		s.enqueue(l, -1)
+		return true
+	}
+	return false
+}
+
+// search runs until it finds a model (1), proves f unsatisfiable (-1), or
+// has seen limit conflicts (0)
+func (s *Solver) search(limit int) int {
+	conflicts := 0
+	for {
+		if conflict := s.propagate(); conflict >= 0 {
+			s.stats.Conflicts++
+			conflicts++
+			if s.decisionLevel() == 0 {
+				return -1
+			}
+			learnt, back := s.analyze(conflict)
+			s.backtrack(back)
+			if len(learnt) == 1 {
+				s.enqueue(learnt[0], -1)
+			} else {
+				s.stats.Learnt++
+				ci := s.attach(learnt)
+				s.lbd[ci] = s.levels(learnt)
+				s.learnts = append(s.learnts, ci)
+				s.enqueue(learnt[0], ci)
+			}
+			s.inc /= 0.95
+			continue
+		}
+		if conflicts >= limit {
+			s.backtrack(0)
+			return 0
+		}
+		if s.stats.Conflicts >= s.nextReduce {
+			s.reduce()
+			s.nextReduce = s.stats.Conflicts + reduceFirst + s.stats.Reductions*reduceStep
+			s.stats.Reductions++
+		}
+		if !s.decide() {
+			return 1
+		}
+	}
+}
+
+// Solve reports whether the formula is satisfiable
+func (s *Solver) Solve() bool {
+	if s.unsat {
+		return false
+	}
+	for i := 1; ; i++ {
+		switch s.search(restartBase * luby(i)) {
+		case 1:
+			s.model = make([]bool, len(s.assign)+1)
+			for v, a := range s.assign {
+				s.model[v+1] = a == 1
+			}
+			s.backtrack(0)
+			return true
+		case -1:
+			s.unsat = true
+			return false
+		}
+		s.stats.Restarts++
+	}
+}
+
+// Model returns the satisfying assignment found by Solve, indexed by
+// variable number, or nil
+func (s *Solver) Model() []bool {
+	return s.model
+}
+
+// Stats returns the work done so far
+func (s *Solver) Stats() Stats {
+	return s.stats
+}
+
+// luby returns the i-th term, from 1, of 1 1 2 1 1 2 4 1 1 2 1 1 2 4 8 ...
+func luby(i int) int {
+	for k := 1; ; k++ {
+		switch full := 1<<k - 1; {
+		case i == full:
+			return 1 << (k - 1)
+		case i < full:
+			return luby(i - (1 << (k - 1)) + 1)
+		}
+	}
+}
+
+// varHeap is a binary max-heap of variables ordered by activity
+type varHeap struct {
+	activity []float64
+	heap     []int
+	pos      []int // index of each variable in heap, -1 when absent
+}
+
+func (h *varHeap) len() int {
+	return len(h.heap)
+}
+
+func (h *varHeap) less(i, j int) bool {
+	return h.activity[h.heap[i]] > h.activity[h.heap[j]]
+}
+
+func (h *varHeap) swap(i, j int) {
+	h.heap[i], h.heap[j] = h.heap[j], h.heap[i]
+	h.pos[h.heap[i]] = i
+	h.pos[h.heap[j]] = j
+}
+
+func (h *varHeap) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(i, parent) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+func (h *varHeap) down(i int) {
+	for {
+		best := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(h.heap) && h.less(child, best) {
+				best = child
+			}
+		}
+		if best == i {
+			return
+		}
+		h.swap(i, best)
+		i = best
+	}
+}
+
+func (h *varHeap) push(v int) {
+	h.pos[v] = len(h.heap)
+	h.heap = append(h.heap, v)
+	h.up(len(h.heap) - 1)
+}
+
+func (h *varHeap) pop() int {
+	v := h.heap[0]
+	h.swap(0, len(h.heap)-1)
+	h.heap = h.heap[:len(h.heap)-1]
+	h.pos[v] = -1
+	if len(h.heap) > 0 {
+		h.down(0)
+	}
+	return v
+}
+
+// update restores the heap after v's activity grew
+func (h *varHeap) update(v int) {
+	if h.pos[v] >= 0 {
+		h.up(h.pos[v])
+	}
+}
@@ -0,0 +1,122 @@
+package sat
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestLuby(t *testing.T) {
+	want := []int{1, 1, 2, 1, 1, 2, 4, 1, 1, 2, 1, 1, 2, 4, 8, 1}
+	for i, w := range want {
+		if got := luby(i + 1); got != w {
+			t.Errorf("luby(%d) = %d, want %d", i+1, got, w)
+		}
+	}
+}
+
+func TestSolveSmall(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    int
+		clauses [][]int
+		want    bool
+	}{
+		{"empty formula", 2, nil, true},
+		{"empty clause", 1, [][]int{{1}, {}}, false},
+		{"contradictory units", 1, [][]int{{1}, {-1}}, false},
+		{"tautology", 1, [][]int{{1, -1}}, true},
+		{"chain", 3, [][]int{{1}, {-1, 2}, {-2, 3}, {-3, -1}}, false},
+		{"repeated literals", 2, [][]int{{1, 1, 2}, {-1, -1}, {-2, 1, -2}}, false},
+	}
+	for _, tc := range tests {
+		f := &CNF{Vars: tc.vars, Clauses: tc.clauses}
+		s := New(f)
+		if got := s.Solve(); got != tc.want {
+			t.Errorf("%s: Solve() = %v, want %v", tc.name, got, tc.want)
+		}
+		if tc.want && !f.Satisfies(s.Model()) {
+			t.Errorf("%s: model %v does not satisfy the formula", tc.name, s.Model())
+		}
+	}
+}
+
+// pigeonhole says n+1 pigeons sit in n holes, one pigeon per hole
+func pigeonhole(n int) *CNF {
+	f := &CNF{}
+	at := make([][]int, n+1)
+	for p := range at {
+		at[p] = make([]int, n)
+		for h := range at[p] {
+			at[p][h] = f.NewVar()
+		}
+		f.Add(at[p]...)
+	}
+	for h := range n {
+		var in []int
+		for p := range at {
+			in = append(in, at[p][h])
+		}
+		f.AtMostOne(in)
+	}
+	return f
+}
+
+func TestPigeonhole(t *testing.T) {
+	for n := 1; n <= 6; n++ {
+		s := New(pigeonhole(n))
+		if s.Solve() {
+			t.Errorf("pigeonhole(%d) is satisfiable", n)
+		}
+		if n > 2 && s.Stats().Learnt == 0 {
+			t.Errorf("pigeonhole(%d) learnt no clauses", n)
+		}
+	}
+}
+
+// bruteForce reports whether any assignment satisfies f
+func bruteForce(f *CNF) bool {
+	model := make([]bool, f.Vars+1)
+	for mask := range 1 << f.Vars {
+		for v := 1; v <= f.Vars; v++ {
+			model[v] = mask>>(v-1)&1 == 1
+		}
+		if f.Satisfies(model) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRandom3SAT(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	sat := 0
+	for range 300 {
+		// Around the 4.26 clauses per variable threshold, half are satisfiable
+		f := &CNF{Vars: 12}
+		for range 51 {
+			var clause []int
+			for range 3 {
+				l := rng.IntN(f.Vars) + 1
+				if rng.IntN(2) == 0 {
+					l = -l
+				}
+				clause = append(clause, l)
+			}
+			f.Add(clause...)
+		}
+		s := New(f)
+		got := s.Solve()
+		if want := bruteForce(f); got != want {
+			t.Fatalf("Solve() = %v, brute force says %v for %v", got, want, f.Clauses)
+		}
+		if got {
+			sat++
+			if !f.Satisfies(s.Model()) {
+				t.Fatalf("model %v does not satisfy %v", s.Model(), f.Clauses)
+			}
+		}
+	}
+	if sat == 0 || sat == 300 {
+		t.Errorf("%d of 300 formulas satisfiable; the test is not exercising both answers", sat)
+	}
+}
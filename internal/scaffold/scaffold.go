@@ -0,0 +1,135 @@
+// Package scaffold generates the files for a new day from templates.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"text/template"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// Kinds lists the available solver templates
+var Kinds = []string{"line", "grid", "graph", "sections"}
+
+// data is what the templates are executed with
+type data struct {
+	Day    int
+	Pkg    string // e.g. "day07"
+	Padded string // e.g. "07"
+}
+
+// file is one generated output
+type file struct {
+	path     string // relative to the repository root
+	template string
+	gofmt    bool
+}
+
+// Generate creates the solver, test and doc stub for day under root using
+// the given template kind, and registers the package in days/all.go. It
+// writes nothing if any of the files already exists. The created paths are
+// returned relative to root.
+func Generate(root string, day int, kind string) ([]string, error) {
+	if day < 1 || day > 25 {
+		return nil, fmt.Errorf("day %d out of range 1-25", day)
+	}
+	if !slices.Contains(Kinds, kind) {
+		return nil, fmt.Errorf("unknown template %q, want one of %v", kind, Kinds)
+	}
+	d := data{Day: day, Pkg: fmt.Sprintf("day%02d", day), Padded: fmt.Sprintf("%02d", day)}
+	files := []file{
+		{filepath.Join("days", d.Pkg, "main.go"), kind + ".go.tmpl", true},
+		{filepath.Join("days", d.Pkg, "main_test.go"), "main_test.go.tmpl", true},
+		{filepath.Join("docs", d.Pkg+".md"), "doc.md.tmpl", false},
+	}
+
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(root, f.path)); err == nil {
+			return nil, fmt.Errorf("%s already exists", f.path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	// Render everything before touching the tree so a template error leaves no debris
+	rendered := make([][]byte, len(files))
+	for i, f := range files {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, f.template, d); err != nil {
+			return nil, err
+		}
+		out := buf.Bytes()
+		if f.gofmt {
+			var err error
+			if out, err = format.Source(out); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.path, err)
+			}
+		}
+		rendered[i] = out
+	}
+	registry := filepath.Join(root, "days", "all.go")
+	allGo, err := register(registry, "aoc2025/days/"+d.Pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []string
+	for i, f := range files {
+		path := filepath.Join(root, f.path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return created, err
+		}
+		if err := os.WriteFile(path, rendered[i], 0o644); err != nil {
+			return created, err
+		}
+		created = append(created, f.path)
+	}
+	if err := os.WriteFile(registry, allGo, 0o644); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// register returns the contents of the days/all.go file at path with a
+// blank import of pkg added, keeping the imports sorted.
+func register(path, pkg string) ([]byte, error) {
+	var imports []string
+	src, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		f, err := parser.ParseFile(token.NewFileSet(), path, src, parser.ImportsOnly)
+		if err != nil {
+			return nil, err
+		}
+		for _, spec := range f.Imports {
+			p, _ := strconv.Unquote(spec.Path.Value)
+			imports = append(imports, p)
+		}
+	}
+	if slices.Contains(imports, pkg) {
+		return nil, fmt.Errorf("%s is already registered in days/all.go", pkg)
+	}
+	imports = append(imports, pkg)
+	slices.Sort(imports)
+
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, "all.go.tmpl", imports); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,111 @@
+package scaffold
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const allGo = `// Package days links every solved day into the aoc registry.
+package days
+
+import (
+	_ "aoc2025/days/day01"
+	_ "aoc2025/days/day12"
+)
+`
+
+func newRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "days"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "days", "all.go"), []byte(allGo), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestGenerate(t *testing.T) {
+	for _, kind := range Kinds {
+		t.Run(kind, func(t *testing.T) {
+			root := newRoot(t)
+			created, err := Generate(root, 7, kind)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []string{"days/day07/main.go", "days/day07/main_test.go", "docs/day07.md"}
+			if strings.Join(created, ",") != strings.Join(want, ",") {
+				t.Errorf("created %v, want %v", created, want)
+			}
+
+			for _, name := range want[:2] {
+				f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(root, name), nil, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if f.Name.Name != "day07" {
+					t.Errorf("%s: package %s, want day07", name, f.Name.Name)
+				}
+			}
+
+			src, _ := os.ReadFile(filepath.Join(root, "days", "day07", "main.go"))
+			if !strings.Contains(string(src), "Number: 7,") {
+				t.Error("solver does not register day 7")
+			}
+			test, _ := os.ReadFile(filepath.Join(root, "days", "day07", "main_test.go"))
+			if !strings.Contains(string(test), `"../../inputs/day07.txt"`) {
+				t.Error("benchmarks are not wired to inputs/day07.txt")
+			}
+			doc, _ := os.ReadFile(filepath.Join(root, "docs", "day07.md"))
+			if !strings.HasPrefix(string(doc), "# Day 07:") {
+				t.Errorf("doc starts with %q", strings.SplitN(string(doc), "\n", 2)[0])
+			}
+
+			all, _ := os.ReadFile(filepath.Join(root, "days", "all.go"))
+			wantAll := strings.Replace(allGo, "\t_ \"aoc2025/days/day12\"", "\t_ \"aoc2025/days/day07\"\n\t_ \"aoc2025/days/day12\"", 1)
+			if string(all) != wantAll {
+				t.Errorf("days/all.go =\n%s\nwant\n%s", all, wantAll)
+			}
+		})
+	}
+}
+
+func TestGenerateRefusesOverwrite(t *testing.T) {
+	root := newRoot(t)
+	doc := filepath.Join(root, "docs", "day03.md")
+	os.MkdirAll(filepath.Dir(doc), 0o755)
+	if err := os.WriteFile(doc, []byte("mine"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Generate(root, 3, "line"); err == nil {
+		t.Fatal("Generate overwrote an existing doc")
+	}
+	if _, err := os.Stat(filepath.Join(root, "days", "day03")); !os.IsNotExist(err) {
+		t.Error("Generate created the package despite refusing")
+	}
+	if all, _ := os.ReadFile(filepath.Join(root, "days", "all.go")); string(all) != allGo {
+		t.Error("Generate modified days/all.go despite refusing")
+	}
+	if data, _ := os.ReadFile(doc); string(data) != "mine" {
+		t.Error("existing doc was modified")
+	}
+}
+
+func TestGenerateRejectsBadArgs(t *testing.T) {
+	root := newRoot(t)
+	if _, err := Generate(root, 0, "line"); err == nil {
+		t.Error("Generate accepted day 0")
+	}
+	if _, err := Generate(root, 5, "hexgrid"); err == nil {
+		t.Error("Generate accepted an unknown template")
+	}
+	if _, err := Generate(root, 1, "line"); err == nil {
+		t.Error("Generate accepted an already registered day")
+	}
+}
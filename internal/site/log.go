@@ -0,0 +1,152 @@
+package site
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"aoc2025/internal/aoc"
+)
+
+// Attempt is one answer sent to the site and how it was judged
+type Attempt struct {
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+// PartLog is the submission history of one puzzle part
+type PartLog struct {
+	Attempts  []Attempt `json:"attempts"`
+	NotBefore time.Time `json:"not_before,omitzero"`
+}
+
+// Log is the on-disk record of submissions, used to avoid repeating known
+// wrong answers and to honour the site's lockout between attempts.
+type Log struct {
+	Parts map[string]*PartLog `json:"parts"`
+}
+
+// ErrKnownWrong is returned by Check for an answer the site has already rejected
+var ErrKnownWrong = errors.New("answer is already known to be wrong")
+
+// ErrSolved is returned by Check when the part already has an accepted answer
+var ErrSolved = errors.New("part is already solved")
+
+// WaitError is returned by Check while the lockout from a previous attempt runs
+type WaitError struct {
+	Remaining time.Duration
+}
+
+func (e *WaitError) Error() string {
+	return fmt.Sprintf("must wait %v before submitting again", e.Remaining.Round(time.Second))
+}
+
+// LogPath returns the location of the submission log under root
+func LogPath(root string) string {
+	return filepath.Join(root, "inputs", "submissions.json")
+}
+
+// LoadLog reads the submission log at path; a missing file is an empty log
+func LoadLog(path string) (*Log, error) {
+	l := &Log{Parts: map[string]*PartLog{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if l.Parts == nil {
+		l.Parts = map[string]*PartLog{}
+	}
+	return l, nil
+}
+
+// Save writes the log to path
+func (l *Log) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return aoc.WriteFileAtomic(path, append(data, '\n'))
+}
+
+func logKey(day, part int) string {
+	return fmt.Sprintf("%d/%d", day, part)
+}
+
+// Part returns the history for day and part, or nil if nothing was submitted
+func (l *Log) Part(day, part int) *PartLog {
+	return l.Parts[logKey(day, part)]
+}
+
+// Accepted returns the answer the site confirmed as correct for day and part
+func (l *Log) Accepted(day, part int) (string, bool) {
+	p := l.Part(day, part)
+	if p == nil {
+		return "", false
+	}
+	for _, a := range p.Attempts {
+		if a.Verdict == Correct {
+			return a.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Check decides whether answer may be submitted at time now. Besides exact
+// repeats it rejects answers outside the bounds set by earlier "too high"
+// and "too low" verdicts.
+func (l *Log) Check(day, part int, answer string, now time.Time) error {
+	p := l.Part(day, part)
+	if p == nil {
+		return nil
+	}
+	n, numErr := strconv.Atoi(answer)
+	for _, a := range p.Attempts {
+		switch {
+		case a.Verdict == Correct:
+			return ErrSolved
+		case a.Verdict.IsWrong() && a.Answer == answer:
+			return fmt.Errorf("%w (%s)", ErrKnownWrong, a.Verdict)
+		}
+		prev, err := strconv.Atoi(a.Answer)
+		if numErr != nil || err != nil {
+			continue
+		}
+		if a.Verdict == TooHigh && n >= prev {
+			return fmt.Errorf("%w (%s was too high)", ErrKnownWrong, a.Answer)
+		}
+		if a.Verdict == TooLow && n <= prev {
+			return fmt.Errorf("%w (%s was too low)", ErrKnownWrong, a.Answer)
+		}
+	}
+	if now.Before(p.NotBefore) {
+		return &WaitError{Remaining: p.NotBefore.Sub(now)}
+	}
+	return nil
+}
+
+// Record stores the outcome of submitting answer at time now
+func (l *Log) Record(day, part int, answer string, out Outcome, now time.Time) {
+	key := logKey(day, part)
+	p := l.Parts[key]
+	if p == nil {
+		p = &PartLog{}
+		l.Parts[key] = p
+	}
+	if out.Verdict != RateLimited {
+		p.Attempts = append(p.Attempts, Attempt{Answer: answer, Verdict: out.Verdict, Time: now})
+	}
+	if out.Wait > 0 {
+		p.NotBefore = now.Add(out.Wait)
+	}
+}
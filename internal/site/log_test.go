@@ -0,0 +1,105 @@
+package site
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLogCheck(t *testing.T) {
+	now := time.Date(2025, 12, 8, 6, 0, 0, 0, time.UTC)
+	l := &Log{Parts: map[string]*PartLog{}}
+	l.Record(8, 1, "500", Outcome{Verdict: TooHigh, Wait: time.Minute}, now)
+	l.Record(8, 1, "100", Outcome{Verdict: TooLow, Wait: time.Minute}, now.Add(2*time.Minute))
+	l.Record(8, 1, "abc", Outcome{Verdict: Wrong}, now.Add(4*time.Minute))
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		answer string
+		at     time.Time
+		want   error
+	}{
+		{"500", later, ErrKnownWrong},
+		{"600", later, ErrKnownWrong},
+		{"100", later, ErrKnownWrong},
+		{"50", later, ErrKnownWrong},
+		{"abc", later, ErrKnownWrong},
+		{"300", later, nil},
+		{"300", now.Add(150 * time.Second), &WaitError{}},
+	}
+	for _, tc := range tests {
+		err := l.Check(8, 1, tc.answer, tc.at)
+		var wait *WaitError
+		switch {
+		case tc.want == nil && err != nil:
+			t.Errorf("Check(%s) = %v, want nil", tc.answer, err)
+		case tc.want == ErrKnownWrong && !errors.Is(err, ErrKnownWrong):
+			t.Errorf("Check(%s) = %v, want ErrKnownWrong", tc.answer, err)
+		case tc.want != nil && tc.want != ErrKnownWrong && !errors.As(err, &wait):
+			t.Errorf("Check(%s) = %v, want WaitError", tc.answer, err)
+		}
+	}
+
+	if err := l.Check(8, 2, "500", later); err != nil {
+		t.Errorf("Check on another part = %v, want nil", err)
+	}
+
+	l.Record(8, 1, "300", Outcome{Verdict: Correct}, later)
+	if err := l.Check(8, 1, "301", later); !errors.Is(err, ErrSolved) {
+		t.Errorf("Check after correct = %v, want ErrSolved", err)
+	}
+}
+
+func TestLogRateLimitedNotAnAttempt(t *testing.T) {
+	now := time.Now()
+	l := &Log{Parts: map[string]*PartLog{}}
+	l.Record(3, 1, "7", Outcome{Verdict: RateLimited, Wait: 30 * time.Second}, now)
+	if n := len(l.Part(3, 1).Attempts); n != 0 {
+		t.Errorf("rate-limited submission recorded %d attempts, want 0", n)
+	}
+	if err := l.Check(3, 1, "7", now.Add(time.Minute)); err != nil {
+		t.Errorf("Check after wait = %v, want nil", err)
+	}
+}
+
+func TestLogAccepted(t *testing.T) {
+	now := time.Now()
+	l := &Log{Parts: map[string]*PartLog{}}
+	if _, ok := l.Accepted(4, 1); ok {
+		t.Error("Accepted on an empty log = true, want false")
+	}
+	l.Record(4, 1, "12", Outcome{Verdict: TooLow}, now)
+	if v, ok := l.Accepted(4, 1); ok {
+		t.Errorf("Accepted after a wrong answer = %q, want none", v)
+	}
+	l.Record(4, 1, "15", Outcome{Verdict: Correct}, now)
+	if v, ok := l.Accepted(4, 1); !ok || v != "15" {
+		t.Errorf("Accepted = %q, %v; want 15, true", v, ok)
+	}
+	if _, ok := l.Accepted(4, 2); ok {
+		t.Error("Accepted for an unsubmitted part = true, want false")
+	}
+}
+
+func TestLogSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "submissions.json")
+	empty, err := LoadLog(path)
+	if err != nil || len(empty.Parts) != 0 {
+		t.Fatalf("LoadLog(missing) = %v, %v", empty, err)
+	}
+
+	now := time.Date(2025, 12, 1, 5, 0, 0, 0, time.UTC)
+	empty.Record(1, 2, "17", Outcome{Verdict: TooLow, Wait: time.Minute}, now)
+	if err := empty.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	l, err := LoadLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := l.Part(1, 2)
+	if p == nil || len(p.Attempts) != 1 || p.Attempts[0].Verdict != TooLow || !p.NotBefore.Equal(now.Add(time.Minute)) {
+		t.Errorf("round-tripped log = %+v", p)
+	}
+}
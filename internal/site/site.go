@@ -0,0 +1,106 @@
+// Package site talks to the Advent of Code website on behalf of a logged-in user.
+package site
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"aoc2025/internal/aoc"
+)
+
+const (
+	// DefaultBaseURL is the Advent of Code website
+	DefaultBaseURL = "https://adventofcode.com"
+	// Year is the event the solutions in this repository belong to
+	Year = 2025
+	// UserAgent identifies this tool to the site operators as they request
+	UserAgent = "github.com/ekroon/adventofcode2025/internal/site"
+)
+
+// ErrNoSession is returned when a request needs a session cookie but none is set
+var ErrNoSession = errors.New("no session cookie: set AOC_SESSION")
+
+// Client performs authenticated requests for one user
+type Client struct {
+	BaseURL string       // site root, DefaultBaseURL unless testing
+	Session string       // value of the "session" cookie
+	HTTP    *http.Client // http.DefaultClient when nil
+}
+
+// New returns a client for the real site using the given session cookie
+func New(session string) *Client {
+	return &Client{
+		BaseURL: DefaultBaseURL,
+		Session: session,
+		HTTP:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Input downloads the puzzle input for day
+func (c *Client) Input(ctx context.Context, day int) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", Year, day), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp, body)
+	}
+	return body, nil
+}
+
+// FetchInput makes sure the input for day exists at path, downloading it
+// only if the file is missing. It reports whether a download happened.
+func (c *Client) FetchInput(ctx context.Context, day int, path string) (bool, error) {
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	data, err := c.Input(ctx, day)
+	if err != nil {
+		return false, err
+	}
+	if err := aoc.WriteFileAtomic(path, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return hc.Do(req)
+}
+
+func statusError(resp *http.Response, body []byte) error {
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > 200 {
+		msg = msg[:200] + "..."
+	}
+	return fmt.Errorf("%s %s: %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status, msg)
+}
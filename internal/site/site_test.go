@@ -0,0 +1,101 @@
+package site
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestClient starts a stand-in site served by h and returns a client for it
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return &Client{BaseURL: srv.URL, Session: "secret", HTTP: srv.Client()}
+}
+
+func TestInput(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2025/day/8/input" {
+			http.NotFound(w, r)
+			return
+		}
+		if ck, err := r.Cookie("session"); err != nil || ck.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if ua := r.UserAgent(); !strings.Contains(ua, "ekroon/adventofcode2025") {
+			t.Errorf("User-Agent = %q, want repository reference", ua)
+		}
+		w.Write([]byte("1,2,3\n4,5,6\n"))
+	})
+
+	got, err := c.Input(context.Background(), 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "1,2,3\n4,5,6\n" {
+		t.Errorf("Input = %q", got)
+	}
+
+	if _, err := c.Input(context.Background(), 9); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Input(9) error = %v, want 404", err)
+	}
+
+	c.Session = "wrong"
+	if _, err := c.Input(context.Background(), 8); err == nil || !strings.Contains(err.Error(), "log in") {
+		t.Errorf("Input with bad session error = %v, want login message", err)
+	}
+
+	c.Session = ""
+	if _, err := c.Input(context.Background(), 8); !errors.Is(err, ErrNoSession) {
+		t.Errorf("Input without session error = %v, want ErrNoSession", err)
+	}
+}
+
+func TestFetchInputCaches(t *testing.T) {
+	var hits atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte("input\n"))
+	})
+	path := filepath.Join(t.TempDir(), "inputs", "day01.txt")
+
+	for i, wantFetched := range []bool{true, false, false} {
+		fetched, err := c.FetchInput(context.Background(), 1, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fetched != wantFetched {
+			t.Errorf("call %d: fetched = %v, want %v", i, fetched, wantFetched)
+		}
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %d times, want 1", n)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "input\n" {
+		t.Errorf("cached file = %q, %v", data, err)
+	}
+}
+
+func TestFetchInputFailureLeavesNoFile(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	dir := t.TempDir()
+	path := filepath.Join(dir, "day02.txt")
+	if _, err := c.FetchInput(context.Background(), 2, path); err == nil {
+		t.Fatal("FetchInput succeeded on a server error")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("directory has %d entries after failed fetch, want 0", len(entries))
+	}
+}
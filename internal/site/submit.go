@@ -0,0 +1,147 @@
+package site
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict classifies the site's response to a submitted answer
+type Verdict int
+
+const (
+	Unknown       Verdict = iota
+	Correct               // the answer was accepted
+	TooHigh               // wrong, and the site says the answer is too high
+	TooLow                // wrong, and the site says the answer is too low
+	Wrong                 // wrong, with no hint
+	RateLimited           // not checked: an answer was given too recently
+	AlreadySolved         // not checked: the part is already complete or locked
+)
+
+var verdictNames = [...]string{
+	Unknown:       "unknown",
+	Correct:       "correct",
+	TooHigh:       "too high",
+	TooLow:        "too low",
+	Wrong:         "wrong",
+	RateLimited:   "rate limited",
+	AlreadySolved: "already solved",
+}
+
+func (v Verdict) String() string {
+	if v < 0 || int(v) >= len(verdictNames) {
+		return "Verdict(" + strconv.Itoa(int(v)) + ")"
+	}
+	return verdictNames[v]
+}
+
+// MarshalText stores verdicts by name so the submission log stays readable
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Verdict) UnmarshalText(b []byte) error {
+	for i, name := range verdictNames {
+		if name == string(b) {
+			*v = Verdict(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown verdict %q", b)
+}
+
+// IsWrong reports whether the answer was checked and rejected
+func (v Verdict) IsWrong() bool {
+	return v == TooHigh || v == TooLow || v == Wrong
+}
+
+// Outcome is the parsed response to a submission
+type Outcome struct {
+	Verdict Verdict
+	Wait    time.Duration // how long until the next attempt is allowed
+	Message string        // the response text with markup removed
+}
+
+var (
+	articleRe = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRe     = regexp.MustCompile(`<[^>]*>`)
+	spaceRe   = regexp.MustCompile(`\s+`)
+	leftRe    = regexp.MustCompile(`You have (?:(\d+)m)?\s*(?:(\d+)s)? left to wait`)
+	pleaseRe  = regexp.MustCompile(`(?i)please wait (\w+) minutes? before trying again`)
+)
+
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+// ParseOutcome extracts the verdict and wait period from a response page
+func ParseOutcome(page []byte) (Outcome, error) {
+	m := articleRe.FindSubmatch(page)
+	if m == nil {
+		return Outcome{}, errors.New("response has no <article>")
+	}
+	text := html.UnescapeString(tagRe.ReplaceAllString(string(m[1]), ""))
+	text = strings.TrimSpace(spaceRe.ReplaceAllString(text, " "))
+	out := Outcome{Message: text}
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		out.Verdict = Correct
+	case strings.Contains(text, "That's not the right answer"):
+		switch {
+		case strings.Contains(text, "too high"):
+			out.Verdict = TooHigh
+		case strings.Contains(text, "too low"):
+			out.Verdict = TooLow
+		default:
+			out.Verdict = Wrong
+		}
+	case strings.Contains(text, "You gave an answer too recently"):
+		out.Verdict = RateLimited
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		out.Verdict = AlreadySolved
+	default:
+		return out, fmt.Errorf("unrecognised response: %q", text)
+	}
+
+	if lm := leftRe.FindStringSubmatch(text); lm != nil {
+		mins, _ := strconv.Atoi(lm[1])
+		secs, _ := strconv.Atoi(lm[2])
+		out.Wait = time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+	} else if pm := pleaseRe.FindStringSubmatch(text); pm != nil {
+		n, err := strconv.Atoi(pm[1])
+		if err != nil {
+			n = numberWords[strings.ToLower(pm[1])]
+		}
+		out.Wait = time.Duration(n) * time.Minute
+	}
+	return out, nil
+}
+
+// Submit posts answer for the given day and part and parses the response
+func (c *Client) Submit(ctx context.Context, day, part int, answer string) (Outcome, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	resp, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", Year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Outcome{}, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Outcome{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Outcome{}, statusError(resp, body)
+	}
+	return ParseOutcome(body)
+}
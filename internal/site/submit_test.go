@@ -0,0 +1,75 @@
+package site
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func readPage(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseOutcome(t *testing.T) {
+	tests := []struct {
+		page    string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		{"correct.html", Correct, 0},
+		{"too_high.html", TooHigh, time.Minute},
+		{"too_low.html", TooLow, 5 * time.Minute},
+		{"wrong.html", Wrong, time.Minute},
+		{"rate_limited.html", RateLimited, 4*time.Minute + 12*time.Second},
+		{"wrong_level.html", AlreadySolved, 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.page, func(t *testing.T) {
+			out, err := ParseOutcome(readPage(t, tc.page))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.Verdict != tc.verdict || out.Wait != tc.wait {
+				t.Errorf("ParseOutcome = %v, %v; want %v, %v", out.Verdict, out.Wait, tc.verdict, tc.wait)
+			}
+		})
+	}
+
+	if _, err := ParseOutcome([]byte("<html><body>maintenance</body></html>")); err == nil {
+		t.Error("ParseOutcome accepted a page without an article")
+	}
+	if _, err := ParseOutcome([]byte("<article><p>Something new</p></article>")); err == nil {
+		t.Error("ParseOutcome accepted an unrecognised message")
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	pages := map[string]string{"42": "correct.html", "100": "too_high.html"}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2025/day/8/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if lvl := r.PostFormValue("level"); lvl != "2" {
+			t.Errorf("level = %q, want 2", lvl)
+		}
+		w.Write(readPage(t, pages[r.PostFormValue("answer")]))
+	})
+
+	out, err := c.Submit(context.Background(), 8, 2, "42")
+	if err != nil || out.Verdict != Correct {
+		t.Errorf("Submit(42) = %v, %v; want correct", out.Verdict, err)
+	}
+	out, err = c.Submit(context.Background(), 8, 2, "100")
+	if err != nil || out.Verdict != TooHigh || out.Wait != time.Minute {
+		t.Errorf("Submit(100) = %v, %v, %v; want too high with a minute wait", out.Verdict, out.Wait, err)
+	}
+}